@@ -0,0 +1,98 @@
+package yamlfmt
+
+import (
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Change made by a Rule to the order of a yaml.MappingNode or yaml.SequenceNode
+type Change struct {
+	// Rule is the Rule.Path of the Rule that made the Change
+	Rule string
+	// Path of the changed node
+	Path string
+	// Line of the changed node in the source document
+	Line int
+	// Moves of the keys (or items) of the node whose index changed
+	Moves []Move
+}
+
+// Move of a single key (or item) within a node
+type Move struct {
+	// Key that moved, items of a yaml.SequenceNode are labelled with their value if they are a yaml.ScalarNode
+	// or with their original index otherwise, e.g. '[0]'
+	Key string
+	// From is the index of the key before the Change
+	From int
+	// To is the index of the key after the Change
+	To int
+	// Line of the key in the source document
+	Line int
+	// Above is the key that directly follows Key after the Change, empty if Key became the last key
+	Above string
+}
+
+// String representation of the Move, e.g. 'moved `operationId` above `parameters`'
+func (m Move) String() string {
+	if m.Above == "" {
+		return fmt.Sprintf("moved `%s` to the end", m.Key)
+	}
+
+	return fmt.Sprintf("moved `%s` above `%s`", m.Key, m.Above)
+}
+
+// LintWithChanges lints the yaml.Node the same as Lint and returns every Change that was made, in the order the
+// rules were applied
+func LintWithChanges(node *yaml.Node, rules []Rule) []Change {
+	var res []Change
+	LintWithTrace(node, rules, func(change Change) {
+		if len(change.Moves) > 0 {
+			res = append(res, change)
+		}
+	})
+
+	return res
+}
+
+// LintWithTrace lints the yaml.Node the same as Lint and calls trace for every node that a Rule matched, in the order
+// the rules were applied. The Change has no Moves if the Rule did not change the order of the node
+func LintWithTrace(node *yaml.Node, rules []Rule, trace func(Change)) {
+	walk(node, rules, func(rule *Rule, _ int, path string, value *yaml.Node) {
+		before := slices.Clone(value.Content)
+		rule.Run(path, value)
+
+		change := Change{Rule: rule.Path, Path: path, Line: value.Line}
+		if !slices.Equal(before, value.Content) {
+			change.Moves = moves(value, before)
+		}
+		trace(change)
+	})
+}
+
+// moves of the keys (or items) of node compared to the content before
+func moves(node *yaml.Node, before []*yaml.Node) []Move {
+	step := 1
+	if node.Kind == yaml.MappingNode {
+		step = 2 // a key=value pair is two yaml.Node's
+	}
+
+	after := labels(node, before)
+	var res []Move
+	for i := 0; i < len(node.Content); i += step {
+		from := slices.Index(before, node.Content[i]) / step
+		to := i / step
+		if from == to {
+			continue
+		}
+
+		move := Move{Key: after[to], From: from, To: to, Line: node.Content[i].Line}
+		if to+1 < len(after) {
+			move.Above = after[to+1]
+		}
+		res = append(res, move)
+	}
+
+	return res
+}
@@ -0,0 +1,104 @@
+package yamlfmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestLintWithChanges(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	b := []byte("paths:\n  /users:\n    get:\n      parameters: []\n      operationId: getUsers\n      responses: {}\n")
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal(b, node))
+	rules := []Rule{NewRule("$.paths[*].get", NewSimpleOrdering("operationId", "parameters", "responses"))}
+
+	// Act
+	changes := LintWithChanges(node, rules)
+
+	// Assert
+	require.Equal(t, []Change{{
+		Rule: "$.paths[*].get",
+		Path: "$.paths./users.get",
+		Line: 4,
+		Moves: []Move{
+			{Key: "operationId", From: 1, To: 0, Line: 5, Above: "parameters"},
+			{Key: "parameters", From: 0, To: 1, Line: 4, Above: "responses"},
+		},
+	}}, changes)
+	assert.Equal(t, "moved `operationId` above `parameters`", changes[0].Moves[0].String())
+	assert.Equal(t, "operationId", node.Content[0].Content[1].Content[1].Content[1].Content[0].Value)
+}
+
+func TestLintWithChanges_Sequence(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte("tags: [b, c, a]\n"), node))
+
+	// Act
+	changes := LintWithChanges(node, []Rule{NewRule("$.tags", StringOrderingFn)})
+
+	// Assert
+	require.Len(t, changes, 1)
+	assert.Equal(t, []Move{
+		{Key: "a", From: 2, To: 0, Line: 1, Above: "b"},
+		{Key: "b", From: 0, To: 1, Line: 1, Above: "c"},
+		{Key: "c", From: 1, To: 2, Line: 1},
+	}, changes[0].Moves)
+	assert.Equal(t, "moved `c` to the end", changes[0].Moves[2].String())
+}
+
+func TestLintWithChanges_NoChanges(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte("a: 1\nb: 2\n"), node))
+
+	// Act
+	changes := LintWithChanges(node, []Rule{NewRule("$", StringOrderingFn)})
+
+	// Assert
+	assert.Empty(t, changes)
+}
+
+func TestLintWithTrace(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte("info:\n  title: API\n  version: \"1\"\ntags: [b, a]\n"), node))
+	rules := []Rule{NewRule("$.info", NewSimpleOrdering("title", "version")), NewRule("$.tags", StringOrderingFn), NewRule("$.servers", StringOrderingFn)}
+
+	// Act
+	var traced []Change
+	LintWithTrace(node, rules, func(change Change) {
+		traced = append(traced, change)
+	})
+
+	// Assert
+	require.Len(t, traced, 2)
+	assert.Equal(t, Change{Rule: "$.info", Path: "$.info", Line: 2}, traced[0])
+	assert.Equal(t, "$.tags", traced[1].Path)
+	assert.Len(t, traced[1].Moves, 2)
+}
+
+func TestLintBytes_WithTrace(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	b := []byte("b: 1\na: 2\n")
+
+	// Act
+	var traced []Change
+	actual, err := LintBytes(b, []Rule{NewRule("$", StringOrderingFn)}, WithTrace(func(change Change) {
+		traced = append(traced, change)
+	}))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "a: 2\nb: 1\n", string(actual))
+	require.Len(t, traced, 1)
+	assert.Equal(t, "$", traced[0].Path)
+}
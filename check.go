@@ -0,0 +1,105 @@
+package yamlfmt
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Violation of a Rule: the order of a yaml.MappingNode or yaml.SequenceNode differs from the order the
+// Rule.Functions would produce
+type Violation struct {
+	// Rule is the Rule.Path of the violated Rule
+	Rule string
+	// Path of the node that violates the Rule
+	Path string
+	// Line of the node in the source document
+	Line int
+	// Column of the node in the source document
+	Column int
+	// Expected order of the keys (or items) after running the Rule.Functions
+	Expected []string
+	// Actual order of the keys (or items)
+	Actual []string
+}
+
+// String representation of the Violation, e.g. '3:1 $.info: expected [title version], got [version title] (rule $.info)'
+func (v Violation) String() string {
+	return fmt.Sprintf("%d:%d %s: expected %v, got %v (rule %s)", v.Line, v.Column, v.Path, v.Expected, v.Actual, v.Rule)
+}
+
+// Check reports every node whose order would be changed by the rules without mutating the node. The rules are
+// evaluated as Lint would (see Lint for the order). An empty result means that Lint would not change the order of the
+// document
+func Check(node *yaml.Node, rules []Rule) []Violation {
+	var res []Violation
+	walk(clone(node, map[*yaml.Node]*yaml.Node{}), rules, func(rule *Rule, _ int, path string, value *yaml.Node) {
+		before := slices.Clone(value.Content)
+		rule.Run(path, value)
+		if slices.Equal(before, value.Content) {
+			return
+		}
+
+		res = append(res, Violation{
+			Rule:     rule.Path,
+			Path:     path,
+			Line:     value.Line,
+			Column:   value.Column,
+			Expected: labels(value, before),
+			Actual:   labels(&yaml.Node{Kind: value.Kind, Content: before}, before),
+		})
+	})
+
+	return res
+}
+
+// labels of the keys of a yaml.MappingNode or of the items of a yaml.SequenceNode. Items that are not a
+// yaml.ScalarNode are labelled with their original index in the original content, e.g. '[0]'
+func labels(node *yaml.Node, original []*yaml.Node) []string {
+	var res []string
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			res = append(res, node.Content[i].Value)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if item.Kind == yaml.ScalarNode {
+				res = append(res, item.Value)
+				continue
+			}
+
+			res = append(res, indexOpen+strconv.Itoa(slices.Index(original, item))+indexClose)
+		}
+	case yaml.DocumentNode, yaml.ScalarNode, yaml.AliasNode:
+	}
+
+	return res
+}
+
+// clone the yaml.Node deeply, seen contains the already cloned nodes such that aliases refer to the cloned anchor
+func clone(node *yaml.Node, seen map[*yaml.Node]*yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+
+	if c, ok := seen[node]; ok {
+		return c
+	}
+
+	c := new(yaml.Node)
+	*c = *node
+	seen[node] = c
+
+	c.Alias = clone(node.Alias, seen)
+	if node.Content != nil {
+		c.Content = make([]*yaml.Node, len(node.Content))
+		for i, content := range node.Content {
+			c.Content[i] = clone(content, seen)
+		}
+	}
+
+	return c
+}
@@ -0,0 +1,88 @@
+package yamlfmt
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestCheck_ReportsViolations(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	b := []byte("info:\n  version: \"1.0\"\n  title: My API\ntags: [b, a]\n")
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal(b, node))
+	rules := []Rule{
+		NewRule("$.info", NewSimpleOrdering("title", "version")),
+		NewRule("$.tags", StringOrderingFn),
+	}
+
+	// Act
+	violations := Check(node, rules)
+
+	// Assert
+	assert.Equal(t, []Violation{
+		{Rule: "$.info", Path: "$.info", Line: 2, Column: 3, Expected: []string{"title", "version"}, Actual: []string{"version", "title"}},
+		{Rule: "$.tags", Path: "$.tags", Line: 4, Column: 7, Expected: []string{"a", "b"}, Actual: []string{"b", "a"}},
+	}, violations)
+	assert.Equal(t, "2:3 $.info: expected [title version], got [version title] (rule $.info)", violations[0].String())
+}
+
+func TestCheck_DoesNotMutate(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	b, err := os.ReadFile("testdata/simple/openapi.yaml")
+	require.NoError(t, err)
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal(b, node))
+	expected := clone(node, map[*yaml.Node]*yaml.Node{})
+
+	// Act
+	violations := Check(node, DefaultOpenAPIRules())
+
+	// Assert
+	assert.NotEmpty(t, violations)
+	assert.Equal(t, expected, node)
+}
+
+func TestCheck_FormattedDocument(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	b, err := os.ReadFile("testdata/simple/openapi.fmt.yaml")
+	require.NoError(t, err)
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal(b, node))
+
+	// Act
+	violations := Check(node, DefaultOpenAPIRules())
+
+	// Assert
+	assert.Empty(t, violations)
+}
+
+func TestCheck_Nil(t *testing.T) {
+	t.Parallel()
+	// Act
+	violations := Check(nil, DefaultOpenAPIRules())
+
+	// Assert
+	assert.Empty(t, violations)
+}
+
+func TestClone_Alias(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte("a: &anchor {b: c}\nd: *anchor\n"), node))
+
+	// Act
+	c := clone(node, map[*yaml.Node]*yaml.Node{})
+
+	// Assert
+	mapping := c.Content[0]
+	assert.NotSame(t, node.Content[0], mapping)
+	assert.Same(t, mapping.Content[1], mapping.Content[3].Alias)
+}
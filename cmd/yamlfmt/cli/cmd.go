@@ -0,0 +1,544 @@
+package cli
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Emptyless/yamlfmt"
+	"github.com/spf13/cobra"
+)
+
+// ErrNotFormatted is returned in --check mode if the input differs from the formatted output
+var ErrNotFormatted = errors.New("file is not formatted")
+
+// exit codes of the command, --check exits with ExitNotFormatted if a file is not formatted and with ExitError on
+// any other error
+const (
+	ExitOK           = 0
+	ExitNotFormatted = 1
+	ExitError        = 2
+)
+
+// ExitCode for the error returned by executing the command of NewRootCmd
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, ErrNotFormatted):
+		return ExitNotFormatted
+	}
+
+	return ExitError
+}
+
+// NewRootCmd creates the formatting command with the name and description, formatting with the yamlfmt.Preset by
+// default unless it is changed with the --preset flag or in the configuration file
+func NewRootCmd(name string, short string, preset string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          name + " [paths...]",
+		Short:        short,
+		Args:         cobra.ArbitraryArgs, // paths, which are not subcommands
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger, err := loggerFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			resolver, err := resolverFromFlags(cmd, logger)
+			if err != nil {
+				return err
+			}
+
+			yaml11, err := cmd.Flags().GetBool("yaml11")
+			if err != nil {
+				return err
+			}
+
+			files, walked, err := filesFromFlags(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			write, err := cmd.Flags().GetBool("write")
+			if err != nil {
+				return err
+			}
+			check, err := cmd.Flags().GetBool("check")
+			if err != nil {
+				return err
+			}
+			diff, err := cmd.Flags().GetBool("diff")
+			if err != nil {
+				return err
+			}
+			outputPath, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+			jobs, err := cmd.Flags().GetInt("jobs")
+			if err != nil {
+				return err
+			}
+			if jobs <= 0 {
+				jobs = runtime.NumCPU()
+			}
+
+			if len(files) > 1 && outputPath != "" {
+				return errors.New("--output can only be used with a single file")
+			}
+			if len(files) > 1 && !write && !check && !diff {
+				return errors.New("multiple files require --write, --check or --diff")
+			}
+
+			stdinName, err := cmd.Flags().GetString("stdin-filename")
+			if err != nil {
+				return err
+			}
+			if write && slices.Contains(files, stdinPath) {
+				return errors.New("--write cannot be used when reading from stdin")
+			}
+
+			f := formatter{resolver: resolver, yaml11: yaml11, trace: logger.Enabled(cmd.Context(), slog.LevelDebug), write: write, stdin: cmd.InOrStdin(), stdinName: stdinName, walked: walked}
+			results := formatFiles(files, jobs, f.format)
+
+			summary := write || len(files) > 1
+			var changed, skipped, failed int
+			for _, res := range results {
+				logResult(logger, res)
+
+				if res.Skipped {
+					skipped++
+					logger.Warn("skipped file", "file", res.Path, "error", res.Err)
+					continue
+				}
+
+				if res.Err != nil {
+					failed++
+					logger.Error("failed to format", "file", res.Path, "error", res.Err)
+					continue
+				}
+
+				if !res.Changed() {
+					if summary {
+						cmd.PrintErrf("%s: unchanged\n", res.Path)
+					}
+					continue
+				}
+
+				changed++
+				if diff {
+					err = printDiff(cmd, res.Path, res.Original, res.Formatted)
+					if err != nil {
+						return err
+					}
+				} else if check {
+					fmt.Fprintln(cmd.OutOrStdout(), res.Path)
+				}
+
+				if summary && write {
+					cmd.PrintErrf("%s: formatted\n", res.Path)
+				} else if summary {
+					cmd.PrintErrf("%s: not formatted\n", res.Path)
+				}
+			}
+
+			if len(files) > 1 {
+				cmd.PrintErrf("%d files: %d changed, %d unchanged, %d skipped, %d failed\n", len(files), changed, len(files)-changed-skipped-failed, skipped, failed)
+			}
+
+			switch {
+			case failed > 0:
+				return fmt.Errorf("%d of %d files failed", failed, len(files))
+			case check && changed > 0:
+				return fmt.Errorf("%d of %d files: %w", changed, len(files), ErrNotFormatted)
+			case check || diff || write || skipped > 0:
+				return nil
+			case outputPath != "":
+				return os.WriteFile(outputPath, results[0].Formatted, 0644)
+			}
+
+			_, err = cmd.OutOrStdout().Write(results[0].Formatted)
+			return err
+		},
+	}
+
+	cmd.Flags().StringP("file", "f", "", "path to yaml file, equal to passing the path as argument, '-' or no paths read from stdin")
+	cmd.Flags().StringP("output", "o", "", "path to output file")
+	cmd.Flags().BoolP("write", "w", false, "write the formatted output back to the source files")
+	cmd.Flags().BoolP("check", "", false, "only check if the files are formatted, lists the files and exits with code 1 if they are not")
+	cmd.Flags().BoolP("diff", "d", false, "print a unified diff of the changes instead of the formatted file")
+	cmd.Flags().IntP("diff-context", "", 3, "number of context lines around every change in --diff") //nolint:mnd // default of diff -u
+	cmd.Flags().StringP("color", "", "auto", "color the --diff output: auto, always or never")
+	cmd.MarkFlagsMutuallyExclusive("check", "output")
+	cmd.MarkFlagsMutuallyExclusive("diff", "output")
+	cmd.MarkFlagsMutuallyExclusive("write", "output")
+	cmd.Flags().StringArrayP("include", "", []string{"*.yaml", "*.yml"}, "glob of the files to format in directories (e.g. 'openapi.yaml' or 'specs/**/*.yaml')")
+	cmd.Flags().StringArrayP("exclude", "", []string{}, "glob of the files and directories to skip in directories (e.g. 'vendor/')")
+	cmd.Flags().BoolP("gitignore", "", true, "skip files in directories that are ignored by .gitignore files")
+	cmd.Flags().IntP("jobs", "j", 0, "number of files to format concurrently, 0 uses the number of CPUs")
+	cmd.Flags().BoolP("yaml11", "", false, "quote plain scalars (e.g. 'on', 'no', 'y') and rewrite numbers (e.g. '0755') that YAML 1.1 parsers interpret differently and report them")
+
+	// flags that determine the rules (and logging) are shared with the subcommands
+	cmd.PersistentFlags().StringP("preset", "p", preset, "preset rules to extend: "+strings.Join(yamlfmt.PresetNames(), ", "))
+	cmd.PersistentFlags().StringP("openapi-version", "", "", "version of the openapi preset: 2.0, 3.0 or 3.1, detected from the 'openapi' or 'swagger' key of every file by default")
+	cmd.PersistentFlags().StringP("extensions", "", "", "place the 'x-' extensions of the objects of the openapi presets at the start or end, the end by default")
+	cmd.PersistentFlags().StringP("stdin-filename", "", "", "path of the file read from stdin, used as its name in the output and to find its "+yamlfmt.ConfigFile+" file")
+	cmd.PersistentFlags().StringP("config", "c", "", "path to a "+yamlfmt.ConfigFile+" file, by default it is discovered by walking up from every file")
+	cmd.PersistentFlags().StringArrayP("alphabetical", "", []string{}, "path to node to sort alphabetically (e.g. '$.key')")
+	cmd.PersistentFlags().StringArrayP("simple", "", []string{}, "path=keys to node to sort (e.g. path = '$.key') with comma separated list of keys")
+	cmd.PersistentFlags().CountP("verbose", "v", "Increase the verbosity of the output by one level, -v shows informational logs and -vv will output debug information.")
+	cmd.PersistentFlags().CountP("quiet", "q", "Decrease the verbosity of the output by one level, -q hides warning logs and -qq will suppress non-fatal errors")
+
+	cmd.AddCommand(newExplainCmd(), newQueryCmd(), newPathsCmd())
+
+	return cmd
+}
+
+// Execute the command of NewRootCmd with the args. An argument that names a subcommand formats the file or directory
+// with that name if it exists in the working directory instead, e.g. an OpenAPI document split into a 'query' directory
+func Execute(cmd *cobra.Command, args []string) error {
+	sub, rest, err := cmd.Find(args)
+	if err == nil && sub != cmd {
+		if _, statErr := os.Stat(sub.Name()); statErr == nil {
+			args = asPath(args, sub.Name(), rest)
+		}
+	}
+
+	cmd.SetArgs(args)
+
+	return cmd.Execute()
+}
+
+// asPath returns the args with the argument name, that the rest of the args remain without, replaced by a relative
+// path that does not name a subcommand
+func asPath(args []string, name string, rest []string) []string {
+	for i, arg := range args {
+		if arg == name && slices.Equal(slices.Concat(args[:i], args[i+1:]), rest) {
+			return slices.Concat(args[:i], []string{"." + string(filepath.Separator) + name}, args[i+1:])
+		}
+	}
+
+	return args
+}
+
+// loggerFromFlags returns the logger for the --verbose and --quiet flags
+func loggerFromFlags(cmd *cobra.Command) (*slog.Logger, error) {
+	verbose, err := cmd.Flags().GetCount("verbose")
+	if err != nil {
+		return nil, err
+	}
+	quiet, err := cmd.Flags().GetCount("quiet")
+	if err != nil {
+		return nil, err
+	}
+
+	return newLogger(cmd.ErrOrStderr(), level(verbose, quiet)), nil
+}
+
+// resolverFromFlags returns the ruleResolver for the --preset, --openapi-version, --extensions, --config,
+// --alphabetical and --simple flags
+func resolverFromFlags(cmd *cobra.Command, logger *slog.Logger) (*ruleResolver, error) {
+	extraRules, err := rulesFromFlags(cmd)
+	if err != nil {
+		return nil, err
+	}
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return nil, err
+	}
+	presetName, err := cmd.Flags().GetString("preset")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = yamlfmt.Preset(presetName); err != nil {
+		return nil, err
+	}
+
+	resolver := &ruleResolver{config: configPath, preset: presetName, forcePreset: cmd.Flags().Changed("preset"), extra: extraRules, logger: logger}
+
+	version, err := cmd.Flags().GetString("openapi-version")
+	if err != nil {
+		return nil, err
+	}
+	if version != "" {
+		resolver.version, err = yamlfmt.ParseVersion(version)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	extensions, err := cmd.Flags().GetString("extensions")
+	if err != nil {
+		return nil, err
+	}
+	if extensions != "" {
+		resolver.extensions, err = yamlfmt.ParseExtensions(extensions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resolver, nil
+}
+
+// rulesFromFlags returns the validated --alphabetical and --simple rules
+func rulesFromFlags(cmd *cobra.Command) ([]yamlfmt.Rule, error) {
+	var rules []yamlfmt.Rule
+	// add alphabetical rules
+	alphabeticalRules, err := cmd.Flags().GetStringArray("alphabetical")
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range alphabeticalRules {
+		rules = append(rules, yamlfmt.NewRule(rule, yamlfmt.StringOrderingFn))
+	}
+
+	// add simple rules
+	simpleRules, err := cmd.Flags().GetStringArray("simple")
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range simpleRules {
+		splitted := strings.SplitN(rule, "=", 2)
+		if len(splitted) != 2 {
+			return nil, fmt.Errorf("invalid rule format: %q, should be key=value,value2,...,valueN", rule)
+		}
+
+		rules = append(rules, yamlfmt.NewRule(splitted[0], yamlfmt.NewSimpleOrdering(strings.Split(splitted[1], ",")...)))
+	}
+
+	// validate rules
+	err = yamlfmt.Validate(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// filesFromFlags returns the files for the --file flag and the positional paths and if they were found by walking a
+// directory, see finder
+func filesFromFlags(cmd *cobra.Command, args []string) ([]string, map[string]bool, error) {
+	filePath, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return nil, nil, err
+	}
+	paths := args
+	if filePath != "" {
+		paths = append([]string{filePath}, args...)
+	}
+	if len(paths) == 0 {
+		return []string{stdinPath}, nil, nil // read from stdin if no paths are provided
+	}
+
+	includeGlobs, err := cmd.Flags().GetStringArray("include")
+	if err != nil {
+		return nil, nil, err
+	}
+	excludeGlobs, err := cmd.Flags().GetStringArray("exclude")
+	if err != nil {
+		return nil, nil, err
+	}
+	gitignore, err := cmd.Flags().GetBool("gitignore")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f := finder{gitignore: gitignore, walked: map[string]bool{}}
+	f.include, err = compilePatterns("", includeGlobs)
+	if err != nil {
+		return nil, nil, err
+	}
+	f.exclude, err = compilePatterns("", excludeGlobs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files, err := f.find(paths)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil, fmt.Errorf("no files found in %v", paths)
+	}
+
+	return files, f.walked, nil
+}
+
+// result of formatting a single file
+type result struct {
+	Path      string
+	Original  []byte
+	Formatted []byte
+	// Quoted scalars of --yaml11
+	Quoted []yamlfmt.Quoted
+	// Rules that the file was formatted with
+	Rules []yamlfmt.Rule
+	// Declared rules of the Rules by the configuration file and the flags, i.e. without the preset
+	Declared []yamlfmt.Rule
+	// Trace of the rules that matched a node, only if the trace of the formatter is enabled
+	Trace []yamlfmt.Change
+	Err   error
+	// Skipped is true if the file was found by walking a directory and Err is a yamlfmt.ErrUnknownVersion, i.e. it
+	// is not an OpenAPI document
+	Skipped bool
+}
+
+// Changed returns true iff the formatted output differs from the original
+func (r result) Changed() bool {
+	return !bytes.Equal(r.Original, r.Formatted)
+}
+
+// formatter formats files (or stdin) with the rules per file
+type formatter struct {
+	// resolver resolves the rules of every file
+	resolver *ruleResolver
+	// yaml11 enables yamlfmt.WithYAML11Quoting
+	yaml11 bool
+	// trace enables yamlfmt.WithTrace
+	trace bool
+	// write the formatted output back to the file if it changed
+	write bool
+	// stdin is read for the stdinPath
+	stdin io.Reader
+	// stdinName is used as the name of stdin in the output and to resolve its rules, if set
+	stdinName string
+	// walked files are skipped if their OpenAPI version is unknown, see finder
+	walked map[string]bool
+}
+
+// format a single file, or stdin if the path is stdinPath
+func (f formatter) format(path string) result {
+	res := result{Path: path}
+	if path == stdinPath {
+		res.Path = cmp.Or(f.stdinName, "<stdin>")
+		var err error
+		res.Original, err = io.ReadAll(f.stdin)
+		if err != nil {
+			res.Err = err
+			return res
+		}
+
+		// resolve the config from the working directory if stdin has no name
+		return f.lint(res, cmp.Or(f.stdinName, "."))
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+
+	res.Original, err = os.ReadFile(path)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+
+	res = f.lint(res, path)
+	res.Skipped = f.walked[filepath.Clean(path)] && errors.Is(res.Err, yamlfmt.ErrUnknownVersion)
+	if res.Err == nil && f.write && res.Changed() {
+		res.Err = os.WriteFile(path, res.Formatted, info.Mode().Perm())
+	}
+
+	return res
+}
+
+// lint the original bytes of the result into the formatted bytes with the rules of the file at path
+func (f formatter) lint(res result, path string) result {
+	var err error
+	res.Rules, res.Declared, err = f.resolver.rulesFor(path, res.Original)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+
+	var opts []yamlfmt.Option
+	if f.yaml11 {
+		opts = append(opts, yamlfmt.WithYAML11Quoting(func(quoted yamlfmt.Quoted) {
+			res.Quoted = append(res.Quoted, quoted)
+		}))
+	}
+	if f.trace {
+		res.Trace = []yamlfmt.Change{}
+		opts = append(opts, yamlfmt.WithTrace(func(change yamlfmt.Change) {
+			res.Trace = append(res.Trace, change)
+		}))
+	}
+
+	res.Formatted, err = yamlfmt.LintBytes(res.Original, res.Rules, opts...)
+	if err != nil {
+		res.Err = err
+	}
+
+	return res
+}
+
+// formatFiles concurrently with a pool of at most jobs workers, the results are in the same order as the paths
+func formatFiles(paths []string, jobs int, fn func(path string) result) []result {
+	results := make([]result, len(paths))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for range max(min(jobs, len(paths)), 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = fn(paths[i])
+			}
+		}()
+	}
+
+	for i := range paths {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// printDiff prints the unified diff of original and formatted using the --diff-context and --color flags
+func printDiff(cmd *cobra.Command, name string, original, formatted []byte) error {
+	context, err := cmd.Flags().GetInt("diff-context")
+	if err != nil {
+		return err
+	}
+	if context < 0 {
+		return fmt.Errorf("invalid --diff-context %d, should be 0 or more", context)
+	}
+
+	colorMode, err := cmd.Flags().GetString("color")
+	if err != nil {
+		return err
+	}
+
+	var color bool
+	switch colorMode {
+	case "always":
+		color = true
+	case "never":
+	case "auto":
+		file, ok := cmd.OutOrStdout().(*os.File)
+		if ok {
+			info, statErr := file.Stat()
+			color = statErr == nil && info.Mode()&os.ModeCharDevice != 0
+		}
+	default:
+		return fmt.Errorf("invalid --color %q, should be auto, always or never", colorMode)
+	}
+
+	_, err = cmd.OutOrStdout().Write([]byte(unifiedDiff(name, original, formatted, context, color)))
+	return err
+}
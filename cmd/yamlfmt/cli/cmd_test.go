@@ -0,0 +1,283 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Emptyless/yamlfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootCmd_Check(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Input    string
+		Listed   bool
+		ExitCode int
+	}{
+		"formatted": {
+			Input:    "a: 1\nb: 2\n",
+			ExitCode: ExitOK,
+		},
+		"not formatted": {
+			Input:    "b: 2\na: 1\n",
+			Listed:   true,
+			ExitCode: ExitNotFormatted,
+		},
+		"invalid yaml": {
+			Input:    "a: [\n",
+			ExitCode: ExitError,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			path := filepath.Join(t.TempDir(), "check.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(test.Input), 0o600))
+			cmd := NewRootCmd("yamlfmt", "", yamlfmt.PresetNone)
+			out := new(bytes.Buffer)
+			cmd.SetOut(out)
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs([]string{"--alphabetical", "$", "--check", path})
+
+			// Act
+			err := cmd.Execute()
+
+			// Assert
+			assert.Equal(t, test.ExitCode, ExitCode(err))
+			if test.Listed {
+				assert.Equal(t, path+"\n", out.String())
+			} else {
+				assert.Empty(t, out.String())
+			}
+		})
+	}
+}
+
+func TestRootCmd_CheckDirectory(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Explicit bool
+		ExitCode int
+	}{
+		"walked": {
+			ExitCode: ExitNotFormatted,
+		},
+		"explicit": {
+			Explicit: true,
+			ExitCode: ExitError,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, yamlfmt.ConfigFile), []byte("preset: openapi\n"), 0o600))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "values.yaml"), []byte("b: 1\na: 2\n"), 0o600))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte("info: {}\nopenapi: 3.1.0\n"), 0o600))
+			cmd := NewRootCmd("openapi-fmt", "", yamlfmt.PresetOpenAPI)
+			out := new(bytes.Buffer)
+			cmd.SetOut(out)
+			cmd.SetErr(new(bytes.Buffer))
+			args := []string{"--check", dir}
+			if test.Explicit {
+				args = append(args, filepath.Join(dir, "values.yaml"))
+			}
+			cmd.SetArgs(args)
+
+			// Act
+			err := cmd.Execute()
+
+			// Assert
+			assert.Equal(t, test.ExitCode, ExitCode(err))
+			assert.Equal(t, filepath.Join(dir, "openapi.yaml")+"\n", out.String())
+		})
+	}
+}
+
+func TestRootCmd_Stdin(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Args []string
+	}{
+		"no path": {
+			Args: []string{},
+		},
+		"dash": {
+			Args: []string{"-"},
+		},
+		"file flag": {
+			Args: []string{"--file", "-"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			cmd := NewRootCmd("yamlfmt", "", yamlfmt.PresetNone)
+			out := new(bytes.Buffer)
+			cmd.SetIn(bytes.NewBufferString("b: 1\na: 2\n"))
+			cmd.SetOut(out)
+			cmd.SetArgs(append([]string{"--alphabetical", "$"}, test.Args...))
+
+			// Act
+			err := cmd.Execute()
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, "a: 2\nb: 1\n", out.String())
+		})
+	}
+}
+
+func TestRootCmd_StdinFilename(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Name     string
+		Expected string
+	}{
+		"config": {
+			Name:     "spec.yaml",
+			Expected: "a: 2\nb: 1\n",
+		},
+		"override": {
+			Name:     "legacy/spec.yaml",
+			Expected: "b: 1\na: 2\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			dir := t.TempDir()
+			config := "preset: none\nrules:\n  - path: $\n    order:\n      - type: alphabetical\noverrides:\n  - files: [\"legacy/*.yaml\"]\n    extend: false\n"
+			require.NoError(t, os.WriteFile(filepath.Join(dir, yamlfmt.ConfigFile), []byte(config), 0o600))
+			cmd := NewRootCmd("openapi-fmt", "", yamlfmt.PresetOpenAPI) // the preset of the config applies
+			out := new(bytes.Buffer)
+			cmd.SetIn(bytes.NewBufferString("b: 1\na: 2\n"))
+			cmd.SetOut(out)
+			cmd.SetArgs([]string{"--stdin-filename", filepath.Join(dir, test.Name)})
+
+			// Act
+			err := cmd.Execute()
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, out.String())
+		})
+	}
+}
+
+func TestRootCmd_StdinWrite(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	cmd := NewRootCmd("yamlfmt", "", yamlfmt.PresetNone)
+	cmd.SetIn(bytes.NewBufferString("a: 1\n"))
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--write", "-"})
+
+	// Act
+	err := cmd.Execute()
+
+	// Assert
+	require.EqualError(t, err, "--write cannot be used when reading from stdin")
+}
+
+func TestRootCmd_Paths(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	path := filepath.Join(t.TempDir(), "explain.yaml") // named after a subcommand
+	require.NoError(t, os.WriteFile(path, []byte("b: 1\na: 2\n"), 0o600))
+	cmd := NewRootCmd("yamlfmt", "", yamlfmt.PresetNone)
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"--alphabetical", "$", path})
+
+	// Act
+	err := cmd.Execute()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "a: 2\nb: 1\n", out.String())
+}
+
+func TestRootCmd_PathsDirectory(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "paths"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "paths", "users.yaml"), []byte("a: 1\nb: 2\n"), 0o600))
+	t.Chdir(dir) // pass the directory by its relative name
+	cmd := NewRootCmd("yamlfmt", "", yamlfmt.PresetNone)
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--alphabetical", "$", "--check", "paths"})
+
+	// Act
+	err := cmd.Execute()
+
+	// Assert
+	require.NoError(t, err)
+}
+
+func TestExecute_SubcommandPath(t *testing.T) {
+	for _, name := range []string{"explain", "query", "list-paths"} {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			dir := t.TempDir()
+			require.NoError(t, os.Mkdir(filepath.Join(dir, name), 0o700))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name, "users.yaml"), []byte("b: 1\na: 2\n"), 0o600))
+			t.Chdir(dir) // pass the directory by the name of the subcommand
+			cmd := NewRootCmd("yamlfmt", "", yamlfmt.PresetNone)
+			out := new(bytes.Buffer)
+			cmd.SetOut(out)
+			cmd.SetErr(new(bytes.Buffer))
+
+			// Act
+			err := Execute(cmd, []string{"--alphabetical", "$", "--check", name})
+
+			// Assert
+			require.ErrorIs(t, err, ErrNotFormatted)
+			assert.Equal(t, filepath.Join(".", name, "users.yaml")+"\n", out.String())
+		})
+	}
+}
+
+func TestExecute_Subcommand(t *testing.T) {
+	// Arrange
+	t.Chdir(t.TempDir()) // without a path named list-paths
+	cmd := NewRootCmd("yamlfmt", "", yamlfmt.PresetNone)
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetIn(bytes.NewBufferString("a: 1\n"))
+
+	// Act
+	err := Execute(cmd, []string{"list-paths"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "$\n$.a\n", out.String())
+}
+
+func TestRootCmd_Extensions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("openapi: \"3.1.0\"\nx-logo: logo.png\n"), 0o600))
+	cmd := NewRootCmd("openapi-fmt", "", yamlfmt.PresetOpenAPI)
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"--extensions", "start", path})
+
+	// Act
+	err := cmd.Execute()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "x-logo: logo.png\nopenapi: \"3.1.0\"\n", out.String())
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+
+	"github.com/Emptyless/yamlfmt"
+	"gopkg.in/yaml.v3"
+)
+
+// ruleResolver resolves the rules of a file from its configuration file, it is safe for concurrent use
+type ruleResolver struct {
+	// config is the path of the --config file, if empty the yamlfmt.ConfigFile is discovered per file
+	config string
+	// preset is the name of the yamlfmt.Preset to use if there is no configuration file
+	preset string
+	// forcePreset overrides the preset of the configuration file (i.e. when --preset is set explicitly)
+	forcePreset bool
+	// version of the yamlfmt.PresetOpenAPI, detected from every file if empty
+	version yamlfmt.Version
+	// extensions overrides the placement of the extensions of the configuration file if set
+	extensions yamlfmt.Extensions
+	// extra rules from flags that are appended to the rules of every file
+	extra []yamlfmt.Rule
+	// configs that are loaded by path
+	configs map[string]*yamlfmt.Config
+	// logger logs the configuration that is used
+	logger *slog.Logger
+
+	// mu guards the configs
+	mu sync.Mutex
+}
+
+// rulesFor the file at path with contents b: the rules of its configuration file (or the preset if there is none)
+// extended with the extra rules. The declared rules are the same rules without the preset
+func (r *ruleResolver) rulesFor(path string, b []byte) ([]yamlfmt.Rule, []yamlfmt.Rule, error) {
+	configPath := r.config
+	if configPath == "" {
+		var err error
+		configPath, err = yamlfmt.FindConfig(path)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var config yamlfmt.Config
+	preset := r.preset
+	if configPath != "" {
+		loaded, err := r.load(configPath)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		config = *loaded // copy, the preset is resolved per file
+		if !r.forcePreset && config.Preset != "" {
+			preset = config.Preset
+		}
+	} else {
+		r.logger.Debug("no config found, using preset", "file", path, "preset", preset)
+	}
+
+	config.Preset = preset
+	if r.extensions != "" {
+		config.Extensions = r.extensions
+	}
+	if config.Extend == nil || *config.Extend {
+		var err error
+		config.Preset, err = r.detect(path, preset, b)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	rules, err := config.RulesFor(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	extend := false
+	config.Extend = &extend
+	declared, err := config.RulesFor(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return append(rules, r.extra...), append(declared, r.extra...), nil
+}
+
+// load the configuration file at path once
+func (r *ruleResolver) load(path string) (*yamlfmt.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if config, ok := r.configs[path]; ok {
+		return config, nil
+	}
+
+	config, err := yamlfmt.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	r.logger.Info("loaded config", "config", path, "preset", config.Preset)
+
+	if r.configs == nil {
+		r.configs = map[string]*yamlfmt.Config{}
+	}
+	r.configs[path] = config
+
+	return config, nil
+}
+
+// detect the preset of the version of the first document in b if the preset is yamlfmt.PresetOpenAPI, or use the
+// preset of the forced version
+func (r *ruleResolver) detect(path string, preset string, b []byte) (string, error) {
+	if preset != yamlfmt.PresetOpenAPI {
+		return preset, nil
+	}
+	if r.version != "" {
+		return r.version.Preset()
+	}
+	if len(bytes.TrimSpace(b)) == 0 {
+		return preset, nil // nothing to format
+	}
+
+	document := new(yaml.Node)
+	err := yaml.NewDecoder(bytes.NewReader(b)).Decode(document)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	detected, err := yamlfmt.DetectPreset(preset, document)
+	if err != nil {
+		return "", err
+	}
+	r.logger.Debug("detected preset", "file", path, "preset", detected)
+
+	return detected, nil
+}
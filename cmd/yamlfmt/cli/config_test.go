@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Emptyless/yamlfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleResolver_Detect(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Preset   string
+		Version  yamlfmt.Version
+		Document string
+		Expected []yamlfmt.Rule
+	}{
+		"swagger": {
+			Preset:   yamlfmt.PresetOpenAPI,
+			Document: "swagger: \"2.0\"\n",
+			Expected: yamlfmt.DefaultSwagger2Rules(),
+		},
+		"openapi 3.1": {
+			Preset:   yamlfmt.PresetOpenAPI,
+			Document: "openapi: 3.1.0\n",
+			Expected: yamlfmt.DefaultOpenAPI31Rules(),
+		},
+		"forced version": {
+			Preset:   yamlfmt.PresetOpenAPI,
+			Version:  yamlfmt.VersionOpenAPI30,
+			Document: "components: {}\n",
+			Expected: yamlfmt.DefaultOpenAPIRules(),
+		},
+		"other preset": {
+			Preset:   yamlfmt.PresetCompose,
+			Document: "services: {}\n",
+			Expected: yamlfmt.DefaultComposeRules(),
+		},
+		"empty document": {
+			Preset:   yamlfmt.PresetOpenAPI,
+			Expected: yamlfmt.DefaultOpenAPIRules(),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			resolver := &ruleResolver{config: "", preset: test.Preset, version: test.Version, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+			// Act
+			rules, declared, err := resolver.rulesFor(t.TempDir(), []byte(test.Document))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, paths(test.Expected), paths(rules))
+			assert.Empty(t, declared)
+		})
+	}
+}
+
+func TestRuleResolver_UnknownVersion(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	resolver := &ruleResolver{preset: yamlfmt.PresetOpenAPI, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	// Act
+	_, _, err := resolver.rulesFor(t.TempDir(), []byte("openapi: 4.0.0\n"))
+
+	// Assert
+	require.ErrorIs(t, err, yamlfmt.ErrUnknownVersion)
+}
+
+func TestRuleResolver_Declared(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	dir := t.TempDir()
+	config := filepath.Join(dir, yamlfmt.ConfigFile)
+	require.NoError(t, os.WriteFile(config, []byte("preset: compose\nrules:\n  - path: $.x-config\n    order:\n      - type: alphabetical\n"), 0o600))
+	resolver := &ruleResolver{config: config, extra: []yamlfmt.Rule{yamlfmt.NewRule("$.services")}, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	// Act
+	rules, declared, err := resolver.rulesFor(filepath.Join(dir, "compose.yaml"), nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, rules, len(yamlfmt.DefaultComposeRules())+2)
+	assert.Equal(t, []string{"$.x-config", "$.services"}, paths(declared))
+}
+
+// paths of the rules
+func paths(rules []yamlfmt.Rule) []string {
+	res := []string{}
+	for _, rule := range rules {
+		res = append(res, rule.Path)
+	}
+
+	return res
+}
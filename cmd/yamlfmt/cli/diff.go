@@ -0,0 +1,294 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ANSI escape codes used to color the unified diff
+const (
+	colorReset  = "\033[0m"
+	colorBold   = "\033[1m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorCyan   = "\033[36m"
+	noNewlineAt = "\\ No newline at end of file\n"
+)
+
+// operation of a single line in an edit script
+type operation int
+
+const (
+	equal operation = iota
+	deletion
+	insertion
+)
+
+// edit of a single line, A and B are the (0-based) line indexes in the original and formatted lines
+type edit struct {
+	Op   operation
+	A, B int
+}
+
+// lines of b including their line endings
+func lines(b []byte) []string {
+	if len(b) == 0 {
+		return nil
+	}
+
+	res := strings.SplitAfter(string(b), "\n")
+	if res[len(res)-1] == "" {
+		res = res[:len(res)-1]
+	}
+
+	return res
+}
+
+// myers computes the shortest edit script to transform a into b with the linear space refinement of
+// "An O(ND) Difference Algorithm and Its Variations" by Eugene W. Myers
+func myers(a, b []string) []edit {
+	size := len(a) + len(b) + 2 //nolint:mnd // diagonals -max-1..max+1
+	d := &differ{a: a, b: b, forward: make([]int, 2*size), backward: make([]int, 2*size), offset: size}
+	d.compare(0, len(a), 0, len(b))
+
+	return deletionsFirst(d.edits)
+}
+
+// deletionsFirst reorders every run of changes in edits so the deletions precede the insertions
+func deletionsFirst(edits []edit) []edit {
+	res := make([]edit, 0, len(edits))
+	for i := 0; i < len(edits); {
+		if edits[i].Op == equal {
+			res = append(res, edits[i])
+			i++
+
+			continue
+		}
+
+		x, y := edits[i].A, edits[i].B
+		var deletions, insertions int
+		for ; i < len(edits) && edits[i].Op != equal; i++ {
+			if edits[i].Op == deletion {
+				deletions++
+			} else {
+				insertions++
+			}
+		}
+		for j := range deletions {
+			res = append(res, edit{Op: deletion, A: x + j, B: y})
+		}
+		for j := range insertions {
+			res = append(res, edit{Op: insertion, A: x + deletions, B: y + j})
+		}
+	}
+
+	return res
+}
+
+// differ holds the lines, the furthest reaching x per diagonal (shared by every call of middleSnake) and the
+// resulting edit script
+type differ struct {
+	a, b              []string
+	forward, backward []int
+	offset            int
+	edits             []edit
+}
+
+// compare a[aLo:aHi] and b[bLo:bHi] and append their edit script
+func (d *differ) compare(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.edits = append(d.edits, edit{Op: equal, A: aLo, B: bLo})
+		aLo++
+		bLo++
+	}
+
+	suffix := 0
+	for aLo < aHi-suffix && bLo < bHi-suffix && d.a[aHi-suffix-1] == d.b[bHi-suffix-1] {
+		suffix++
+	}
+	aHi -= suffix
+	bHi -= suffix
+
+	switch {
+	case aLo == aHi:
+		for y := bLo; y < bHi; y++ {
+			d.edits = append(d.edits, edit{Op: insertion, A: aLo, B: y})
+		}
+	case bLo == bHi:
+		for x := aLo; x < aHi; x++ {
+			d.edits = append(d.edits, edit{Op: deletion, A: x, B: bLo})
+		}
+	default:
+		x, y, u, v := d.middleSnake(aLo, aHi, bLo, bHi)
+		d.compare(aLo, x, bLo, y)
+		for ; x < u; x, y = x+1, y+1 {
+			d.edits = append(d.edits, edit{Op: equal, A: x, B: y})
+		}
+		d.compare(u, aHi, v, bHi)
+	}
+
+	for i := range suffix {
+		d.edits = append(d.edits, edit{Op: equal, A: aHi + i, B: bHi + i})
+	}
+}
+
+// middleSnake of a[aLo:aHi] and b[bLo:bHi] from (x, y) to (u, v), found by searching from both ends until the
+// furthest reaching paths overlap
+func (d *differ) middleSnake(aLo, aHi, bLo, bHi int) (x, y, u, v int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta%2 != 0
+	fwd, bwd, off := d.forward, d.backward, d.offset
+	fwd[off+1], bwd[off+1] = 0, 0
+	for depth := 0; depth <= (n+m+1)/2; depth++ {
+		// forward from (aLo, bLo), x and y are relative to the start
+		for k := -depth; k <= depth; k += 2 {
+			var x int
+			if k == -depth || (k != depth && fwd[off+k-1] < fwd[off+k+1]) {
+				x = fwd[off+k+1] // move down: insertion
+			} else {
+				x = fwd[off+k-1] + 1 // move right: deletion
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && d.a[aLo+x] == d.b[bLo+y] {
+				x++
+				y++
+			}
+			fwd[off+k] = x
+			if odd && delta-k >= -(depth-1) && delta-k <= depth-1 && x+bwd[off+delta-k] >= n {
+				return aLo + startX, bLo + startY, aLo + x, bLo + y
+			}
+		}
+
+		// backward from (aHi, bHi), x and y are relative to the end
+		for k := -depth; k <= depth; k += 2 {
+			var x int
+			if k == -depth || (k != depth && bwd[off+k-1] < bwd[off+k+1]) {
+				x = bwd[off+k+1]
+			} else {
+				x = bwd[off+k-1] + 1
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && d.a[aHi-x-1] == d.b[bHi-y-1] {
+				x++
+				y++
+			}
+			bwd[off+k] = x
+			if !odd && delta-k >= -depth && delta-k <= depth && x+fwd[off+delta-k] >= n {
+				return aHi - x, bHi - y, aHi - startX, bHi - startY
+			}
+		}
+	}
+
+	panic("no middle snake") // unreachable, the paths overlap at depth (n+m+1)/2 at the latest
+}
+
+// unifiedDiff of the original and formatted bytes with context lines around every change, returns an empty string
+// if there are no differences. If color is true ANSI escape codes are used to highlight the changes
+func unifiedDiff(name string, original, formatted []byte, context int, color bool) string {
+	a, b := lines(original), lines(formatted)
+	edits := myers(a, b)
+
+	paint := func(code string, s string) string {
+		if !color {
+			return s
+		}
+
+		return code + strings.TrimSuffix(s, "\n") + colorReset + "\n"
+	}
+
+	// line of the diff for an edit, including a marker if the line has no trailing newline
+	line := func(e edit) string {
+		var prefix, text, code string
+		switch e.Op {
+		case equal:
+			prefix, text = " ", a[e.A]
+		case deletion:
+			prefix, text, code = "-", a[e.A], colorRed
+		case insertion:
+			prefix, text, code = "+", b[e.B], colorGreen
+		}
+
+		res := paint(code, prefix+strings.TrimSuffix(text, "\n")+"\n")
+		if !strings.HasSuffix(text, "\n") {
+			res += noNewlineAt
+		}
+
+		return res
+	}
+
+	out := new(strings.Builder)
+	for start := 0; start < len(edits); {
+		// find next change
+		for start < len(edits) && edits[start].Op == equal {
+			start++
+		}
+		if start == len(edits) {
+			break
+		}
+
+		// extend the hunk until there are more than 2*context equal lines between changes
+		end := start
+		for i := start; i < len(edits); i++ {
+			if edits[i].Op != equal {
+				end = i + 1
+			} else if i-end >= 2*context {
+				break
+			}
+		}
+
+		from := max(start-context, 0)
+		to := min(end+context, len(edits))
+		if out.Len() == 0 {
+			out.WriteString(paint(colorBold, "--- "+name+"\n"))
+			out.WriteString(paint(colorBold, "+++ "+name+"\n"))
+		}
+		out.WriteString(paint(colorCyan, header(edits[from:to])))
+		for _, e := range edits[from:to] {
+			out.WriteString(line(e))
+		}
+
+		start = to
+	}
+
+	return out.String()
+}
+
+// header of a hunk, e.g. '@@ -1,4 +1,5 @@'
+func header(hunk []edit) string {
+	aStart, bStart := hunk[0].A+1, hunk[0].B+1
+	var aLen, bLen int
+	for _, e := range hunk {
+		switch e.Op {
+		case equal:
+			aLen++
+			bLen++
+		case deletion:
+			aLen++
+		case insertion:
+			bLen++
+		}
+	}
+
+	// an empty range starts at the line before the hunk
+	if aLen == 0 {
+		aStart--
+	}
+	if bLen == 0 {
+		bStart--
+	}
+
+	return fmt.Sprintf("@@ -%s +%s @@\n", hunkRange(aStart, aLen), hunkRange(bStart, bLen))
+}
+
+// hunkRange formats the start and length of a hunk, the length is omitted if it is 1
+func hunkRange(start, length int) string {
+	if length == 1 {
+		return strconv.Itoa(start)
+	}
+
+	return fmt.Sprintf("%d,%d", start, length)
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Original  string
+		Formatted string
+		Context   int
+		Expected  string
+	}{
+		"no changes": {
+			Original:  "a\nb\n",
+			Formatted: "a\nb\n",
+			Context:   3,
+			Expected:  "",
+		},
+		"swapped lines": {
+			Original:  "a\nb\nc\nd\n",
+			Formatted: "a\nc\nb\nd\n",
+			Context:   1,
+			Expected:  "--- f\n+++ f\n@@ -1,4 +1,4 @@\n a\n-b\n c\n+b\n d\n",
+		},
+		"separate hunks": {
+			Original:  "a\nb\nc\nd\ne\nf\n",
+			Formatted: "x\nb\nc\nd\ne\ny\n",
+			Context:   1,
+			Expected:  "--- f\n+++ f\n@@ -1,2 +1,2 @@\n-a\n+x\n b\n@@ -5,2 +5,2 @@\n e\n-f\n+y\n",
+		},
+		"insertion without context": {
+			Original:  "a\nc\n",
+			Formatted: "a\nb\nc\n",
+			Context:   0,
+			Expected:  "--- f\n+++ f\n@@ -1,0 +2 @@\n+b\n",
+		},
+		"missing newline": {
+			Original:  "a\nb",
+			Formatted: "a\nb\n",
+			Context:   3,
+			Expected:  "--- f\n+++ f\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+		"empty original": {
+			Original:  "",
+			Formatted: "a\n",
+			Context:   3,
+			Expected:  "--- f\n+++ f\n@@ -0,0 +1 @@\n+a\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			actual := unifiedDiff("f", []byte(test.Original), []byte(test.Formatted), test.Context, false)
+
+			// Assert
+			assert.Equal(t, test.Expected, actual)
+		})
+	}
+}
+
+func TestUnifiedDiff_Color(t *testing.T) {
+	t.Parallel()
+	// Act
+	actual := unifiedDiff("f", []byte("a\n"), []byte("b\n"), 0, true)
+
+	// Assert
+	assert.Equal(t, colorBold+"--- f"+colorReset+"\n"+colorBold+"+++ f"+colorReset+"\n"+colorCyan+"@@ -1 +1 @@"+colorReset+"\n"+colorRed+"-a"+colorReset+"\n"+colorGreen+"+b"+colorReset+"\n", actual)
+}
+
+func TestUnifiedDiff_Large(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	original, formatted := new(strings.Builder), new(strings.Builder)
+	for i := range 20000 {
+		line := "key" + strconv.Itoa(i) + ": value\n"
+		original.WriteString(line)
+		if i%10 == 0 {
+			line = "key" + strconv.Itoa(i) + ": changed\n"
+		}
+		formatted.WriteString(line)
+	}
+
+	// Act
+	actual := unifiedDiff("f", []byte(original.String()), []byte(formatted.String()), 0, false)
+
+	// Assert
+	assert.Equal(t, 2000, strings.Count(actual, "\n-key"))
+	assert.Equal(t, 2000, strings.Count(actual, "\n+key"))
+	assert.Contains(t, actual, "@@ -19991 +19991 @@\n-key19990: value\n+key19990: changed\n")
+}
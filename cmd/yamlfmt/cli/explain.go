@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Emptyless/yamlfmt"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// newExplainCmd creates the command that explains which rules match a node
+func newExplainCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain --path path [file]",
+		Short: "list the rules that match the node at the path in the order they are applied",
+		Long: "list the rules that match the node at the (canonical) path, e.g. '$.paths./users.get', in the order they " +
+			"are applied with the functions of every rule and the order of the keys before and after the rule is applied",
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := cmd.Flags().GetString("path")
+			if err != nil {
+				return err
+			}
+
+			logger, err := loggerFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			resolver, err := resolverFromFlags(cmd, logger)
+			if err != nil {
+				return err
+			}
+
+			file := stdinPath
+			if len(args) > 0 {
+				file = args[0]
+			}
+			b, rules, err := readWithRules(cmd, resolver, file)
+			if err != nil {
+				return err
+			}
+
+			documents, err := decodeAll(b)
+			if err != nil {
+				return err
+			}
+
+			var found bool
+			for i, document := range documents {
+				explanations, explainErr := yamlfmt.Explain(document, rules, path)
+				if errors.Is(explainErr, yamlfmt.ErrNodeNotFound) {
+					continue
+				}
+				if explainErr != nil {
+					return explainErr
+				}
+
+				found = true
+				if len(documents) > 1 {
+					fmt.Fprintf(cmd.OutOrStdout(), "document %d: ", i+1)
+				}
+				printExplanations(cmd, path, explanations)
+			}
+			if !found {
+				return fmt.Errorf("%q: %w", path, yamlfmt.ErrNodeNotFound)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("path", "", "", "canonical path of the node to explain (e.g. '$.paths./users.get')")
+	_ = cmd.MarkFlagRequired("path")
+
+	return cmd
+}
+
+// readWithRules reads the file (or stdin if it is the stdinPath) and resolves its rules
+func readWithRules(cmd *cobra.Command, resolver *ruleResolver, file string) ([]byte, []yamlfmt.Rule, error) {
+	b, err := readInput(cmd, file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := file
+	if file == stdinPath {
+		// resolve the config from the --stdin-filename like the root command, or from the working directory
+		path, err = cmd.Flags().GetString("stdin-filename")
+		if err != nil {
+			return nil, nil, err
+		}
+		path = cmp.Or(path, ".")
+	}
+	rules, _, err := resolver.rulesFor(path, b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return b, rules, nil
+}
+
+// readInput reads the file, or stdin if it is the stdinPath
+func readInput(cmd *cobra.Command, file string) ([]byte, error) {
+	if file == stdinPath {
+		return io.ReadAll(cmd.InOrStdin())
+	}
+
+	return os.ReadFile(file)
+}
+
+// decodeAll documents of a (multi-document) yaml stream
+func decodeAll(b []byte) ([]*yaml.Node, error) {
+	var documents []*yaml.Node
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+	for {
+		document := new(yaml.Node)
+		err := decoder.Decode(document)
+		if errors.Is(err, io.EOF) {
+			return documents, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+}
+
+// printExplanations of the node at path, e.g.
+//
+//	$.paths./users.get: 2 rules
+//	#11 $.paths[*].get: StringOrderingFn, NewSimpleOrdering
+//	  before: responses, operationId
+//	  after:  operationId, responses
+func printExplanations(cmd *cobra.Command, path string, explanations []yamlfmt.Explanation) {
+	out := cmd.OutOrStdout()
+	if len(explanations) == 0 {
+		fmt.Fprintf(out, "%s: no rules\n", path)
+		return
+	}
+
+	fmt.Fprintf(out, "%s: %d rules\n", path, len(explanations))
+	for _, explanation := range explanations {
+		unchanged := ""
+		if !explanation.Changed() {
+			unchanged = " (unchanged)"
+		}
+
+		fmt.Fprintf(out, "#%d %s: %s%s\n", explanation.Index, explanation.Rule, strings.Join(explanation.Functions, ", "), unchanged)
+		fmt.Fprintf(out, "  before: %s\n", strings.Join(explanation.Before, ", "))
+		fmt.Fprintf(out, "  after:  %s\n", strings.Join(explanation.After, ", "))
+	}
+}
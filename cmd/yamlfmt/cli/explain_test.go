@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Emptyless/yamlfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainCmd(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	cmd := NewRootCmd("yamlfmt", "", yamlfmt.PresetNone)
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetIn(strings.NewReader("b: 1\na: 2\n---\nc: 3\n"))
+	cmd.SetArgs([]string{"explain", "--path", "$", "--alphabetical", "$", "--simple", "$=a,b"})
+
+	// Act
+	err := cmd.Execute()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, `document 1: $: 2 rules
+#0 $: StringOrderingFn
+  before: b, a
+  after:  a, b
+#1 $: NewSimpleOrdering (unchanged)
+  before: a, b
+  after:  a, b
+document 2: $: 2 rules
+#0 $: StringOrderingFn (unchanged)
+  before: c
+  after:  c
+#1 $: NewSimpleOrdering (unchanged)
+  before: c
+  after:  c
+`, out.String())
+}
+
+func TestExplainCmd_NotFound(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	cmd := NewRootCmd("yamlfmt", "", yamlfmt.PresetNone)
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetIn(strings.NewReader("a: 1\n"))
+	cmd.SetArgs([]string{"explain", "--path", "$.b"})
+
+	// Act
+	err := cmd.Execute()
+
+	// Assert
+	require.ErrorIs(t, err, yamlfmt.ErrNodeNotFound)
+	assert.Equal(t, ExitError, ExitCode(err))
+}
+
+func TestExplainCmd_StdinFilename(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	dir := t.TempDir()
+	config := "rules:\n  - path: $\n    order:\n      - type: alphabetical\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, yamlfmt.ConfigFile), []byte(config), 0o600))
+	cmd := NewRootCmd("yamlfmt", "", yamlfmt.PresetNone)
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetIn(strings.NewReader("b: 1\na: 2\n"))
+	cmd.SetArgs([]string{"explain", "--path", "$", "--stdin-filename", filepath.Join(dir, "spec.yaml")})
+
+	// Act
+	err := cmd.Execute()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "$: 1 rules\n#0 $: StringOrderingFn\n  before: b, a\n  after:  a, b\n", out.String())
+}
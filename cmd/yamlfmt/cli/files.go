@@ -0,0 +1,346 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Emptyless/yamlfmt"
+)
+
+// stdinPath is the path that reads from stdin instead of a file
+const stdinPath = "-"
+
+// gitignoreFile is read in every walked directory if .gitignore awareness is enabled
+const gitignoreFile = ".gitignore"
+
+// pattern is a compiled glob in .gitignore syntax, used for --include, --exclude and .gitignore files
+type pattern struct {
+	// base directory (slash separated, relative to the walked root or for .gitignore files to the root of its
+	// repository) the pattern is relative to
+	base string
+	// regexp of the glob
+	regexp *regexp.Regexp
+	// anchored patterns are matched against the path relative to base, others against the name of the file
+	anchored bool
+	// negate patterns start with '!' and re-include a previously excluded path
+	negate bool
+	// dirOnly patterns end with '/' and only match directories
+	dirOnly bool
+}
+
+// compile a glob in .gitignore syntax relative to the base directory:
+// '*' matches anything except '/', '?' matches a single character except '/', '**' matches any number of directories
+// and a pattern that contains a '/' (other than a trailing one) is matched against the path relative to base
+func compile(base string, glob string) (pattern, error) {
+	p := pattern{base: base}
+	if strings.HasPrefix(glob, "!") {
+		p.negate = true
+		glob = glob[1:]
+	}
+	if strings.HasSuffix(glob, "/") {
+		p.dirOnly = true
+		glob = strings.TrimSuffix(glob, "/")
+	}
+	if strings.Contains(glob, "/") {
+		p.anchored = true
+		glob = strings.TrimPrefix(glob, "/")
+	}
+
+	var err error
+	p.regexp, err = yamlfmt.GlobRegexp(glob)
+	if err != nil {
+		return p, err
+	}
+
+	return p, nil
+}
+
+// match returns true iff the slash separated path (relative to the same directory as the base) matches the pattern
+func (p pattern) match(rel string, dir bool) bool {
+	if p.dirOnly && !dir {
+		return false
+	}
+
+	if p.base != "" {
+		if !strings.HasPrefix(rel, p.base+"/") {
+			return false // pattern of another directory
+		}
+		rel = strings.TrimPrefix(rel, p.base+"/")
+	}
+
+	if !p.anchored {
+		rel = path.Base(rel)
+	}
+
+	return p.regexp.MatchString(rel)
+}
+
+// patterns is an ordered set of pattern where the last matching pattern decides
+type patterns []pattern
+
+// compilePatterns relative to the base directory, blank lines and comments ('#') are skipped
+func compilePatterns(base string, globs []string) (patterns, error) {
+	var res patterns
+	for _, glob := range globs {
+		glob = strings.TrimSpace(glob)
+		if glob == "" || strings.HasPrefix(glob, "#") {
+			continue
+		}
+
+		p, err := compile(base, glob)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, p)
+	}
+
+	return res, nil
+}
+
+// match returns true iff the last pattern that matches the path is not negated
+func (ps patterns) match(rel string, dir bool) bool {
+	var res bool
+	for _, p := range ps {
+		if p.match(rel, dir) {
+			res = !p.negate
+		}
+	}
+
+	return res
+}
+
+// readGitignore of a directory, returns no patterns if the directory has no .gitignore file
+func readGitignore(dir string, base string) (patterns, error) {
+	file, err := os.Open(filepath.Join(dir, gitignoreFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var globs []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		globs = append(globs, scanner.Text())
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return compilePatterns(base, globs)
+}
+
+// finder discovers the files to format from the paths provided on the command line
+type finder struct {
+	// include patterns a file in a directory must match
+	include patterns
+	// exclude patterns skip files and directories
+	exclude patterns
+	// gitignore enables reading .gitignore files in walked directories
+	gitignore bool
+	// walked records (by their cleaned path) if the files were found by walking a directory (true) or were provided
+	// explicitly (false), explicitly provided files take precedence. Nothing is recorded if it is nil
+	walked map[string]bool
+}
+
+// record the file at path as walked or explicitly provided, see walked
+func (f finder) record(path string, walked bool) {
+	if f.walked == nil {
+		return
+	}
+
+	path = filepath.Clean(path)
+	if _, ok := f.walked[path]; !ok || !walked {
+		f.walked[path] = walked
+	}
+}
+
+// find the files for the provided paths in order without duplicates. Files (and the stdinPath) are always included,
+// directories are walked recursively where files must match the include patterns and must not be excluded or ignored.
+// Paths that do not exist are expanded as a glob
+func (f finder) find(paths []string) ([]string, error) {
+	var res []string
+	for _, p := range paths {
+		if p == stdinPath {
+			res = append(res, p)
+			continue
+		}
+
+		info, err := os.Stat(p)
+		if errors.Is(err, fs.ErrNotExist) {
+			matches, globErr := filepath.Glob(p)
+			if globErr != nil || len(matches) == 0 {
+				return nil, err // neither an existing path nor a glob with matches
+			}
+
+			for _, match := range matches {
+				found, findErr := f.find([]string{match})
+				if findErr != nil {
+					return nil, findErr
+				}
+				res = append(res, found...)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			f.record(p, false)
+			res = append(res, p)
+			continue
+		}
+
+		found, err := f.walk(p)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, found...)
+	}
+
+	return unique(res), nil
+}
+
+// unique returns the paths without duplicates, keeping the first occurrence
+func unique(paths []string) []string {
+	var res []string
+	seen := map[string]bool{}
+	for _, p := range paths {
+		if seen[filepath.Clean(p)] {
+			continue
+		}
+
+		seen[filepath.Clean(p)] = true
+		res = append(res, p)
+	}
+
+	return res
+}
+
+// readGitignoreParents reads the .gitignore files of the parent directories of dir up to the root of its git repository
+// (the directory that contains '.git'), returns no patterns if dir is not in a repository. The patterns and the returned
+// prefix (the slash separated path of dir) are relative to the root of the repository
+func readGitignoreParents(dir string) (patterns, string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var parents []string
+	for current := abs; ; {
+		_, err = os.Stat(filepath.Join(current, ".git"))
+		if err == nil {
+			break
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return nil, "", nil // not in a repository
+		}
+		current = parent
+		parents = append(parents, current)
+	}
+
+	top := abs
+	if len(parents) > 0 {
+		top = parents[len(parents)-1]
+	}
+
+	var res patterns
+	for i := len(parents) - 1; i >= 0; i-- {
+		base, relErr := slashRel(top, parents[i])
+		if relErr != nil {
+			return nil, "", relErr
+		}
+
+		gitignored, readErr := readGitignore(parents[i], base)
+		if readErr != nil {
+			return nil, "", readErr
+		}
+		res = append(res, gitignored...)
+	}
+
+	prefix, err := slashRel(top, abs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return res, prefix, nil
+}
+
+// slashRel returns the slash separated path of target relative to base, which is empty if they are the same
+func slashRel(base, target string) (string, error) {
+	rel, err := filepath.Rel(base, target)
+	if err != nil || rel == "." {
+		return "", err
+	}
+
+	return filepath.ToSlash(rel), nil
+}
+
+// walk the directory recursively and return every file that is included and not excluded or ignored, the
+// yamlfmt.ConfigFile is never included
+func (f finder) walk(dir string) ([]string, error) {
+	var ignored patterns
+	var prefix string // of the ignored paths, see readGitignoreParents
+	if f.gitignore {
+		var err error
+		ignored, prefix, err = readGitignoreParents(dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var res []string
+	err := filepath.WalkDir(dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if entry.IsDir() {
+			if rel == "." {
+				rel = ""
+			} else if entry.Name() == ".git" || f.exclude.match(rel, true) || ignored.match(path.Join(prefix, rel), true) {
+				return filepath.SkipDir
+			}
+
+			if f.gitignore {
+				gitignored, readErr := readGitignore(p, path.Join(prefix, rel))
+				if readErr != nil {
+					return readErr
+				}
+				ignored = append(ignored, gitignored...)
+			}
+
+			return nil
+		}
+
+		if entry.Name() == yamlfmt.ConfigFile {
+			return nil
+		}
+
+		if f.include.match(rel, false) && !f.exclude.match(rel, false) && !ignored.match(path.Join(prefix, rel), false) {
+			f.record(p, true)
+			res = append(res, p)
+		}
+
+		return nil
+	})
+
+	return res, err
+}
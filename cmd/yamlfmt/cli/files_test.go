@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatterns_Match(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Base     string
+		Globs    []string
+		Path     string
+		Dir      bool
+		Expected bool
+	}{
+		"name at any depth": {
+			Globs:    []string{"*.yaml"},
+			Path:     "a/b/openapi.yaml",
+			Expected: true,
+		},
+		"other extension": {
+			Globs: []string{"*.yaml"},
+			Path:  "a/b/openapi.json",
+		},
+		"anchored": {
+			Globs: []string{"/openapi.yaml"},
+			Path:  "a/openapi.yaml",
+		},
+		"double star": {
+			Globs:    []string{"specs/**/*.yaml"},
+			Path:     "specs/a/b/openapi.yaml",
+			Expected: true,
+		},
+		"directory only": {
+			Globs: []string{"vendor/"},
+			Path:  "vendor",
+		},
+		"directory": {
+			Globs:    []string{"vendor/"},
+			Path:     "vendor",
+			Dir:      true,
+			Expected: true,
+		},
+		"negated": {
+			Globs: []string{"*.yaml", "!keep.yaml"},
+			Path:  "keep.yaml",
+		},
+		"relative to base": {
+			Base:     "sub",
+			Globs:    []string{"/generated.yaml"},
+			Path:     "sub/generated.yaml",
+			Expected: true,
+		},
+		"outside of base": {
+			Base:  "sub",
+			Globs: []string{"generated.yaml"},
+			Path:  "generated.yaml",
+		},
+		"character class": {
+			Globs:    []string{"v[0-9].yaml"},
+			Path:     "v1.yaml",
+			Expected: true,
+		},
+		"comments and blank lines": {
+			Globs: []string{"# *.yaml", ""},
+			Path:  "openapi.yaml",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			ps, err := compilePatterns(test.Base, test.Globs)
+			require.NoError(t, err)
+
+			// Act
+			ok := ps.match(test.Path, test.Dir)
+
+			// Assert
+			assert.Equal(t, test.Expected, ok)
+		})
+	}
+}
+
+func TestCompile_UnterminatedClass(t *testing.T) {
+	t.Parallel()
+	// Act
+	_, err := compile("", "[a-z")
+
+	// Assert
+	require.EqualError(t, err, "invalid glob \"[a-z\": unterminated character class")
+}
+
+func TestFinder_Find(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		".gitignore":             "ignored/\n*.gen.yaml\n",
+		"a.yaml":                 "",
+		"b.yml":                  "",
+		"c.json":                 "",
+		"x.gen.yaml":             "",
+		"ignored/d.yaml":         "",
+		"vendor/e.yaml":          "",
+		"nested/.gitignore":      "!*.gen.yaml\n",
+		"nested/f.yaml":          "",
+		"nested/g.gen.yaml":      "",
+		".git/config.yaml":       "",
+		"nested/deeper/h.yaml":   "",
+		"nested/deeper/i.txt":    "",
+		"nested/deeper/j.gen.ya": "",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	include, err := compilePatterns("", []string{"*.yaml", "*.yml"})
+	require.NoError(t, err)
+	exclude, err := compilePatterns("", []string{"vendor/"})
+	require.NoError(t, err)
+	f := finder{include: include, exclude: exclude, gitignore: true}
+
+	// Act
+	files, err := f.find([]string{dir, filepath.Join(dir, "c.json"), filepath.Join(dir, "a.yaml")})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "a.yaml"),
+		filepath.Join(dir, "b.yml"),
+		filepath.Join(dir, "nested", "deeper", "h.yaml"),
+		filepath.Join(dir, "nested", "f.yaml"),
+		filepath.Join(dir, "nested", "g.gen.yaml"),
+		filepath.Join(dir, "c.json"),
+	}, files)
+}
+
+func TestFinder_FindGlob(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), nil, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yaml"), nil, 0o600))
+
+	// Act
+	files, err := finder{}.find([]string{filepath.Join(dir, "*.yaml")})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a.yaml"), filepath.Join(dir, "b.yaml")}, files)
+}
+
+func TestFinder_FindGitignoreParents(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		".git/HEAD":                     "",
+		".gitignore":                    "*.gen.yaml\nspecs/nested/ignored/\n",
+		"specs/.gitignore":              "/nested/local.yaml\n",
+		"specs/nested/a.yaml":           "",
+		"specs/nested/b.gen.yaml":       "",
+		"specs/nested/local.yaml":       "",
+		"specs/nested/ignored/c.yaml":   "",
+		"specs/nested/deeper/.gitkeep":  "",
+		"specs/nested/deeper/d.gen.yml": "",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	include, err := compilePatterns("", []string{"*.yaml", "*.yml"})
+	require.NoError(t, err)
+	f := finder{include: include, gitignore: true}
+
+	// Act
+	files, err := f.find([]string{filepath.Join(dir, "specs", "nested")})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "specs", "nested", "a.yaml"), filepath.Join(dir, "specs", "nested", "deeper", "d.gen.yml")}, files)
+}
+
+func TestFinder_FindNotExist(t *testing.T) {
+	t.Parallel()
+	// Act
+	_, err := finder{}.find([]string{filepath.Join(t.TempDir(), "missing.yaml")})
+
+	// Assert
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFormatFiles_KeepsOrder(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	paths := []string{"a", "b", "c", "d", "e"}
+
+	// Act
+	results := formatFiles(paths, 2, func(path string) result {
+		return result{Path: path}
+	})
+
+	// Assert
+	require.Len(t, results, len(paths))
+	for i, res := range results {
+		assert.Equal(t, paths[i], res.Path)
+	}
+}
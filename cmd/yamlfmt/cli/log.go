@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"io"
+	"log/slog"
+)
+
+// levelStep is the distance between the slog levels, e.g. slog.LevelInfo and slog.LevelWarn
+const levelStep = 4
+
+// level of the logs for the count of the --verbose and --quiet flags, slog.LevelWarn by default
+func level(verbose, quiet int) slog.Level {
+	return slog.LevelWarn + slog.Level(levelStep*(quiet-verbose))
+}
+
+// newLogger writes text logs of at least the level to w, without the time to keep the output stable
+func newLogger(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	}))
+}
+
+// logResult logs the quoted scalars as warnings, the rules as debug information (including the declared rules that
+// matched no nodes) and the outcome of formatting the file as information
+func logResult(logger *slog.Logger, res result) {
+	for _, quoted := range res.Quoted {
+		if quoted.Replacement != "" {
+			logger.Warn("rewrote number, YAML 1.1 resolves it differently", "file", res.Path, "value", quoted.Value, "replacement", quoted.Replacement, "line", quoted.Line, "column", quoted.Column, "path", quoted.Path, "tag", quoted.Tag)
+			continue
+		}
+
+		logger.Warn("quoted scalar, YAML 1.1 resolves it differently", "file", res.Path, "value", quoted.Value, "line", quoted.Line, "column", quoted.Column, "path", quoted.Path, "tag", quoted.Tag)
+	}
+
+	matched := map[string]bool{}
+	for _, change := range res.Trace {
+		matched[change.Rule] = true
+		logger.Debug("rule matched", "file", res.Path, "rule", change.Rule, "path", change.Path, "line", change.Line, "changed", len(change.Moves) > 0)
+		for _, move := range change.Moves {
+			logger.Debug(move.String(), "file", res.Path, "rule", change.Rule, "path", change.Path, "line", move.Line, "from", move.From, "to", move.To)
+		}
+	}
+	if res.Trace != nil {
+		for _, rule := range res.Declared { // a preset has rules for objects that most documents do not have
+			if !matched[rule.Path] && !rule.Opaque {
+				logger.Debug("rule matched no nodes", "file", res.Path, "rule", rule.Path)
+			}
+		}
+	}
+
+	if res.Err == nil {
+		logger.Info("formatted", "file", res.Path, "rules", len(res.Rules), "changed", res.Changed())
+	}
+}
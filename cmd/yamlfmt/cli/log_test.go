@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/Emptyless/yamlfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevel(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Verbose  int
+		Quiet    int
+		Expected slog.Level
+	}{
+		"default": {
+			Expected: slog.LevelWarn,
+		},
+		"verbose": {
+			Verbose:  1,
+			Expected: slog.LevelInfo,
+		},
+		"very verbose": {
+			Verbose:  2,
+			Expected: slog.LevelDebug,
+		},
+		"quiet": {
+			Quiet:    1,
+			Expected: slog.LevelError,
+		},
+		"very quiet": {
+			Quiet:    2,
+			Expected: slog.LevelError + levelStep,
+		},
+		"verbose and quiet": {
+			Verbose:  1,
+			Quiet:    1,
+			Expected: slog.LevelWarn,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			actual := level(test.Verbose, test.Quiet)
+
+			// Assert
+			assert.Equal(t, test.Expected, actual)
+		})
+	}
+}
+
+func TestLogResult(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	out := new(bytes.Buffer)
+	logger := newLogger(out, slog.LevelDebug)
+	rules := []yamlfmt.Rule{yamlfmt.NewRule("$", yamlfmt.StringOrderingFn), yamlfmt.NewRule("$.info", yamlfmt.StringOrderingFn)}
+	res := result{
+		Path:      "openapi.yaml",
+		Original:  []byte("b: 1\na: 2\n"),
+		Formatted: []byte("a: 2\nb: 1\n"),
+		Rules:     rules,
+		Declared:  rules[1:],
+		Trace: []yamlfmt.Change{{
+			Rule:  "$",
+			Path:  "$",
+			Line:  1,
+			Moves: []yamlfmt.Move{{Key: "a", From: 1, To: 0, Line: 2, Above: "b"}},
+		}},
+	}
+
+	// Act
+	logResult(logger, res)
+
+	// Assert
+	assert.Equal(t, `level=DEBUG msg="rule matched" file=openapi.yaml rule=$ path=$ line=1 changed=true
+level=DEBUG msg="moved `+"`a`"+` above `+"`b`"+`" file=openapi.yaml rule=$ path=$ line=2 from=1 to=0
+level=DEBUG msg="rule matched no nodes" file=openapi.yaml rule=$.info
+level=INFO msg=formatted file=openapi.yaml rules=2 changed=true
+`, out.String())
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Emptyless/yamlfmt"
+	"github.com/spf13/cobra"
+)
+
+// newPathsCmd creates the command that lists the path of every node
+func newPathsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-paths [file]",
+		Short: "list the path of every node as it is matched against the path of a rule",
+		Long: "list the path of every node as it is matched against the path of a rule, paths that no rule can select " +
+			"exactly (e.g. keys that contain a '.') are marked as '# not addressable'",
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kind, err := cmd.Flags().GetBool("kind")
+			if err != nil {
+				return err
+			}
+			line, err := cmd.Flags().GetBool("line")
+			if err != nil {
+				return err
+			}
+
+			file := stdinPath
+			if len(args) > 0 {
+				file = args[0]
+			}
+			b, err := readInput(cmd, file)
+			if err != nil {
+				return err
+			}
+
+			documents, err := decodeAll(b)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			for i, document := range documents {
+				if i > 0 {
+					fmt.Fprintln(out, "---")
+				}
+
+				for _, path := range yamlfmt.Paths(document) {
+					columns := []string{path.Path}
+					if kind {
+						columns = append(columns, yamlfmt.KindName(path.Kind))
+					}
+					if line {
+						columns = append(columns, fmt.Sprintf("%d:%d", path.Line, path.Column))
+					}
+					if !path.Addressable {
+						columns = append(columns, "# not addressable")
+					}
+					fmt.Fprintln(out, strings.Join(columns, "\t"))
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolP("kind", "k", false, "print the kind of every node: mapping, sequence, scalar or alias")
+	cmd.Flags().BoolP("line", "l", false, "print the line:column of every node")
+
+	return cmd
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Emptyless/yamlfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathsCmd(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Args     []string
+		Input    string
+		Expected string
+	}{
+		"paths": {
+			Args:     []string{"list-paths"},
+			Input:    "responses:\n  200: {description: OK}\n",
+			Expected: "$\n$.responses\n$.responses.200\n$.responses.200.description\n",
+		},
+		"kind and line": {
+			Args:     []string{"list-paths", "--kind", "--line"},
+			Input:    "tags: [a]\n",
+			Expected: "$\tmapping\t1:1\n$.tags\tsequence\t1:7\n$.tags[0]\tscalar\t1:8\n",
+		},
+		"not addressable": {
+			Args:     []string{"list-paths"},
+			Input:    "x.y: 1\n",
+			Expected: "$\n$.x.y\t# not addressable\n",
+		},
+		"multiple documents": {
+			Args:     []string{"list-paths"},
+			Input:    "a: 1\n---\nb: 2\n",
+			Expected: "$\n$.a\n---\n$\n$.b\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			cmd := NewRootCmd("yamlfmt", "", yamlfmt.PresetNone)
+			out := new(bytes.Buffer)
+			cmd.SetOut(out)
+			cmd.SetIn(strings.NewReader(test.Input))
+			cmd.SetArgs(test.Args)
+
+			// Act
+			err := cmd.Execute()
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, out.String())
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/Emptyless/yamlfmt"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// queryResult is a yamlfmt.Match in the output of the query command
+type queryResult struct {
+	// Document is the (1-based) index of the document in a multi-document stream, 0 for a single document
+	Document int             `json:"document,omitempty"`
+	Path     string          `json:"path"`
+	Line     int             `json:"line"`
+	Column   int             `json:"column"`
+	Value    json.RawMessage `json:"value"`
+
+	node *yaml.Node
+}
+
+// newQueryCmd creates the command that prints the nodes that match a path
+func newQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query path [file]",
+		Short: "print the nodes that match the path with their canonical paths and line numbers",
+		Long: "print the nodes that match the path, using the same syntax as the rules (e.g. '$.paths[*].get' or " +
+			"'.schema'), with their canonical paths and line numbers",
+		Args:         cobra.RangeArgs(1, 2), //nolint:mnd // path and optional file
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := cmd.Flags().GetString("format")
+			if err != nil {
+				return err
+			}
+			if format != "yaml" && format != "json" {
+				return fmt.Errorf("invalid --format %q, should be yaml or json", format)
+			}
+
+			file := stdinPath
+			if len(args) > 1 {
+				file = args[1]
+			}
+			b, err := readInput(cmd, file)
+			if err != nil {
+				return err
+			}
+
+			documents, err := decodeAll(b)
+			if err != nil {
+				return err
+			}
+
+			results := []queryResult{}
+			for i, document := range documents {
+				matches, queryErr := yamlfmt.Query(document, args[0])
+				if queryErr != nil {
+					return queryErr
+				}
+
+				for _, match := range matches {
+					res := queryResult{Path: match.Path, Line: match.Line, Column: match.Column, node: match.Node}
+					if len(documents) > 1 {
+						res.Document = i + 1
+					}
+					results = append(results, res)
+				}
+			}
+
+			if format == "json" {
+				return printJSON(cmd, results)
+			}
+
+			return printYAML(cmd, results)
+		},
+	}
+
+	cmd.Flags().StringP("format", "", "yaml", "output format: yaml or json")
+
+	return cmd
+}
+
+// printJSON prints the results as a JSON array, the values keep the order of the keys of their node
+func printJSON(cmd *cobra.Command, results []queryResult) error {
+	for i := range results {
+		var err error
+		results[i].Value, err = toJSON(results[i].node)
+		if err != nil {
+			return fmt.Errorf("%s: %w", results[i].Path, err)
+		}
+	}
+
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(results)
+}
+
+// toJSON encodes the node as JSON in the order of its keys, the keys of a mapping are encoded as strings
+func toJSON(node *yaml.Node) ([]byte, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return []byte("null"), nil
+		}
+		return toJSON(node.Content[0])
+	case yaml.AliasNode:
+		return toJSON(node.Alias)
+	case yaml.MappingNode, yaml.SequenceNode:
+		open, closing, step := byte('['), byte(']'), 1
+		if node.Kind == yaml.MappingNode {
+			open, closing, step = '{', '}', 2 //nolint:mnd // a key=value pair is two yaml.Node's
+		}
+
+		buf := new(bytes.Buffer)
+		buf.WriteByte(open)
+		for i := 0; i+step-1 < len(node.Content); i += step {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if step == 2 { //nolint:mnd // key of a key=value pair
+				key, err := json.Marshal(node.Content[i].Value)
+				if err != nil {
+					return nil, err
+				}
+				buf.Write(key)
+				buf.WriteByte(':')
+			}
+
+			value, err := toJSON(node.Content[i+step-1])
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(value)
+		}
+		buf.WriteByte(closing)
+		return buf.Bytes(), nil
+	case yaml.ScalarNode:
+	}
+
+	var value any
+	err := node.Decode(&value)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(value)
+}
+
+// printYAML prints the results as a YAML sequence, the values keep the style and comments of their node
+func printYAML(cmd *cobra.Command, results []queryResult) error {
+	sequence := &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{}}
+	for _, res := range results {
+		item := &yaml.Node{Kind: yaml.MappingNode}
+		if res.Document > 0 {
+			item.Content = append(item.Content, scalar("document"), intScalar(res.Document))
+		}
+		item.Content = append(item.Content,
+			scalar("path"), scalar(res.Path),
+			scalar("line"), intScalar(res.Line),
+			scalar("column"), intScalar(res.Column),
+			scalar("value"), res.node,
+		)
+		sequence.Content = append(sequence.Content, item)
+	}
+
+	encoder := yaml.NewEncoder(cmd.OutOrStdout())
+	encoder.SetIndent(2) //nolint:mnd // same indentation as yamlfmt.LintBytes
+	err := encoder.Encode(sequence)
+	if err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// scalar string node
+func scalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// intScalar integer node
+func intScalar(value int) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(value)}
+}
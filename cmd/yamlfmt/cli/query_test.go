@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Emptyless/yamlfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestQueryCmd(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Args     []string
+		Input    string
+		Expected string
+	}{
+		"yaml": {
+			Args:     []string{"query", ".name"},
+			Input:    "items:\n  - name: 'a' # first\n  - name: b\n",
+			Expected: "- path: $.items[0].name\n  line: 2\n  column: 11\n  value: 'a' # first\n- path: $.items[1].name\n  line: 3\n  column: 11\n  value: b\n",
+		},
+		"json": {
+			Args:     []string{"query", "$.b", "--format", "json"},
+			Input:    "b:\n  z: 1\n  a: [true, null]\n",
+			Expected: "[\n  {\n    \"path\": \"$.b\",\n    \"line\": 2,\n    \"column\": 3,\n    \"value\": {\n      \"z\": 1,\n      \"a\": [\n        true,\n        null\n      ]\n    }\n  }\n]\n",
+		},
+		"multiple documents": {
+			Args:     []string{"query", "$.kind"},
+			Input:    "kind: A\n---\nkind: B\n",
+			Expected: "- document: 1\n  path: $.kind\n  line: 1\n  column: 7\n  value: A\n- document: 2\n  path: $.kind\n  line: 3\n  column: 7\n  value: B\n",
+		},
+		"no matches": {
+			Args:     []string{"query", "$.c"},
+			Input:    "b: 1\n",
+			Expected: "[]\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			cmd := NewRootCmd("yamlfmt", "", yamlfmt.PresetNone)
+			out := new(bytes.Buffer)
+			cmd.SetOut(out)
+			cmd.SetIn(strings.NewReader(test.Input))
+			cmd.SetArgs(test.Args)
+
+			// Act
+			err := cmd.Execute()
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, out.String())
+		})
+	}
+}
+
+func TestToJSON(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte("b: &x {d: 1.5, c: \"s\"}\na: *x\n"), node))
+
+	// Act
+	actual, err := toJSON(node)
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"b":{"d":1.5,"c":"s"},"a":{"d":1.5,"c":"s"}}`, string(actual))
+	assert.Equal(t, `{"b":{"d":1.5,"c":"s"},"a":{"d":1.5,"c":"s"}}`, string(actual))
+}
@@ -0,0 +1,17 @@
+module github.com/Emptyless/yamlfmt/cmd/yamlfmt
+
+go 1.24.1
+
+require (
+	github.com/Emptyless/yamlfmt v0.2.0
+	github.com/spf13/cobra v1.9.1
+	github.com/stretchr/testify v1.10.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/spf13/pflag v1.0.6 // indirect
+)
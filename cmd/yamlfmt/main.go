@@ -0,0 +1,13 @@
+package main
+
+import (
+	"os"
+
+	"github.com/Emptyless/yamlfmt"
+	"github.com/Emptyless/yamlfmt/cmd/yamlfmt/cli"
+)
+
+func main() {
+	cmd := cli.NewRootCmd("yamlfmt", "opinionated formatter of yaml files", yamlfmt.PresetNone)
+	os.Exit(cli.ExitCode(cli.Execute(cmd, os.Args[1:])))
+}
@@ -0,0 +1,347 @@
+package yamlfmt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFile is the name of the configuration file discovered by FindConfig
+const ConfigFile = ".yamlfmt.yaml"
+
+// ErrUnknownOrder is returned when an OrderConfig.Type is not supported
+var ErrUnknownOrder = errors.New("unknown order type")
+
+// Config declares rules in a ConfigFile, e.g.
+//
+//	preset: openapi # the Preset to extend, 'openapi' if not set or 'none' for no preset
+//	extend: true # extend the preset (default) or replace it with false
+//	extensions: end # place the 'x-' keys of the objects of the preset at the start or end
+//	rules:
+//	  - path: $.info
+//	    order:
+//	      - type: alphabetical
+//	      - type: simple
+//	        keys: [title, version]
+//	overrides:
+//	  - files: ["legacy/**/*.yaml"]
+//	    extend: false
+//	    rules:
+//	      - path: $.paths
+//	        order:
+//	          - type: alphabetical
+type Config struct {
+	// Preset to extend, see Preset, DefaultOpenAPIRules if not set. The command detects the version of PresetOpenAPI
+	// from every file with DetectPreset
+	Preset string `yaml:"preset,omitempty"`
+	// Extend the Preset with the Rules if true (or not set), otherwise the Rules replace them
+	Extend *bool `yaml:"extend,omitempty"`
+	// Extensions places the specification extensions ('x-' keys) of the objects of the Preset at the start or end,
+	// the placement of the Preset (the end for the openapi presets) if not set
+	Extensions Extensions `yaml:"extensions,omitempty"`
+	// Rules to apply
+	Rules []RuleConfig `yaml:"rules,omitempty"`
+	// Overrides for files that match a glob, applied in order
+	Overrides []OverrideConfig `yaml:"overrides,omitempty"`
+
+	// dir of the ConfigFile, Overrides globs are relative to this directory
+	dir string
+}
+
+// RuleConfig declares a Rule
+type RuleConfig struct {
+	// Path of the Rule, see Rule.Path
+	Path string `yaml:"path"`
+	// Schema is true if the Path addresses JSON Schemas, see Rule.Schema
+	Schema bool `yaml:"schema,omitempty"`
+	// Opaque is true if the Path addresses user data that is never ordered, see Rule.Opaque
+	Opaque bool `yaml:"opaque,omitempty"`
+	// Order to apply on matching nodes, in order
+	Order []OrderConfig `yaml:"order"`
+}
+
+// OrderConfig declares an OrderFn with its parameters
+type OrderConfig struct {
+	// Type of the OrderFn:
+	// 'alphabetical' (StringOrderingFn),
+	// 'paths' (PathOrderingFn),
+	// 'responses' (ResponseOrderingFn),
+	// 'simple' (NewSimpleOrdering with Keys),
+	// 'literal' or 'folded' (NewBlockScalarStyling with Width and Chomping)
+	Type string `yaml:"type"`
+	// Keys of the 'simple' type
+	Keys []string `yaml:"keys,omitempty"`
+	// Width of the 'literal' and 'folded' types, see NewBlockScalarStyling
+	Width int `yaml:"width,omitempty"`
+	// Chomping of the 'literal' and 'folded' types: 'strip', 'clip' or 'keep', any chomping if empty
+	Chomping string `yaml:"chomping,omitempty"`
+}
+
+// OverrideConfig declares rules for the files that match one of the Files globs
+type OverrideConfig struct {
+	// Files globs relative to the directory of the ConfigFile, '**' matches any number of directories and a glob
+	// without a '/' matches the name of the file
+	Files []string `yaml:"files"`
+	// Extend the rules that apply so far if true (or not set), otherwise the Rules replace them
+	Extend *bool `yaml:"extend,omitempty"`
+	// Rules to apply on the matching files
+	Rules []RuleConfig `yaml:"rules,omitempty"`
+}
+
+// FindConfig walks up from the directory of path (a file or directory) and returns the path of the first ConfigFile
+// that is found, or an empty string if there is none
+func FindConfig(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(abs)
+	if err != nil || !info.IsDir() {
+		abs = filepath.Dir(abs) // a file, or a file that does not exist (e.g. the name of stdin)
+	}
+
+	for {
+		candidate := filepath.Join(abs, ConfigFile)
+		_, err = os.Stat(candidate)
+		if err == nil {
+			return candidate, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return "", nil // reached the root
+		}
+		abs = parent
+	}
+}
+
+// LoadConfig reads and parses the ConfigFile at path
+func LoadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := ParseConfig(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	config.dir, err = filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// ParseConfig parses the contents of a ConfigFile, unknown fields are an error. Overrides globs of a parsed Config
+// are relative to the working directory
+func ParseConfig(b []byte) (*Config, error) {
+	config := new(Config)
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+	decoder.KnownFields(true)
+	err := decoder.Decode(config)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	// build all rules once to report errors early
+	_, err = config.preset()
+	if err != nil {
+		return nil, err
+	}
+	if config.Extensions != "" {
+		if _, err = ParseExtensions(string(config.Extensions)); err != nil {
+			return nil, err
+		}
+	}
+	_, err = buildRules(config.Rules)
+	if err != nil {
+		return nil, err
+	}
+	for _, override := range config.Overrides {
+		for _, glob := range override.Files {
+			if _, err = GlobRegexp(glob); err != nil {
+				return nil, err
+			}
+		}
+
+		_, err = buildRules(override.Rules)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return config, nil
+}
+
+// RulesFor the file at path: the rules of the Config.Preset (unless replaced) with the Config.Rules and the rules of
+// every matching OverrideConfig
+func (c *Config) RulesFor(path string) ([]Rule, error) {
+	var rules []Rule
+	if c.Extend == nil || *c.Extend {
+		var err error
+		rules, err = c.preset()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	configRules, err := buildRules(c.Rules)
+	if err != nil {
+		return nil, err
+	}
+	rules = append(rules, configRules...)
+
+	rel, err := c.rel(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, override := range c.Overrides {
+		ok, matchErr := override.match(rel)
+		if matchErr != nil {
+			return nil, matchErr
+		}
+		if !ok {
+			continue
+		}
+
+		overrideRules, buildErr := buildRules(override.Rules)
+		if buildErr != nil {
+			return nil, buildErr
+		}
+
+		if override.Extend != nil && !*override.Extend {
+			rules = nil
+		}
+		rules = append(rules, overrideRules...)
+	}
+
+	return rules, nil
+}
+
+// preset returns the rules of the Config.Preset ('openapi' if not set) with the Config.Extensions
+func (c *Config) preset() ([]Rule, error) {
+	name := c.Preset
+	if name == "" {
+		name = PresetOpenAPI
+	}
+
+	rules, err := Preset(name)
+	if err != nil || c.Extensions == "" {
+		return rules, err
+	}
+
+	return WithExtensions(rules, c.Extensions), nil
+}
+
+// rel returns the slash separated path relative to the directory of the Config
+func (c *Config) rel(path string) (string, error) {
+	dir := c.dir
+	if dir == "" {
+		var err error
+		dir, err = os.Getwd()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(dir, abs)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.ToSlash(rel), nil
+}
+
+// match returns true iff the slash separated path matches one of the OverrideConfig.Files globs
+func (o OverrideConfig) match(rel string) (bool, error) {
+	for _, glob := range o.Files {
+		re, err := GlobRegexp(glob)
+		if err != nil {
+			return false, err
+		}
+
+		name := rel
+		if !strings.Contains(glob, "/") {
+			name = path.Base(rel)
+		}
+		if re.MatchString(name) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// buildRules converts the RuleConfig into validated rules
+func buildRules(configs []RuleConfig) ([]Rule, error) {
+	rules := make([]Rule, 0, len(configs))
+	for _, config := range configs {
+		rule := NewRule(config.Path)
+		rule.Schema = config.Schema
+		rule.Opaque = config.Opaque
+		for _, order := range config.Order {
+			fn, err := order.build()
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %w", config.Path, err)
+			}
+			rule.Functions = append(rule.Functions, fn)
+		}
+		rules = append(rules, rule)
+	}
+
+	err := Validate(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// build the OrderFn of the OrderConfig
+func (o OrderConfig) build() (OrderFn, error) {
+	switch o.Type {
+	case "alphabetical":
+		return StringOrderingFn, nil
+	case "paths":
+		return PathOrderingFn, nil
+	case "responses":
+		return ResponseOrderingFn, nil
+	case "simple":
+		return NewSimpleOrdering(o.Keys...), nil
+	case "literal", "folded":
+		style := yaml.LiteralStyle
+		if o.Type == "folded" {
+			style = yaml.FoldedStyle
+		}
+
+		chomping, ok := map[string]Chomping{"": ChompAny, "strip": ChompStrip, "clip": ChompClip, "keep": ChompKeep}[o.Chomping]
+		if !ok {
+			return nil, fmt.Errorf("invalid chomping %q, should be strip, clip or keep", o.Chomping)
+		}
+
+		return NewBlockScalarStyling(style, o.Width, chomping), nil
+	}
+
+	return nil, fmt.Errorf("%q: %w", o.Type, ErrUnknownOrder)
+}
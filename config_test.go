@@ -0,0 +1,261 @@
+package yamlfmt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// configDocument extends the defaults, replaces them for legacy files, sorts the tags of files named openapi.yaml and
+// the servers of files in versioned directories other than v1
+const configDocument = `rules:
+  - path: $.info
+    order:
+      - type: simple
+        keys: [version, title]
+  - path: .description
+    order:
+      - type: literal
+overrides:
+  - files: ["legacy/**/*.yaml"]
+    extend: false
+    rules:
+      - path: $
+        order:
+          - type: alphabetical
+  - files: [openapi.yaml]
+    rules:
+      - path: $.tags
+        order:
+          - type: alphabetical
+  - files: ["/v[!1]/*.yaml"]
+    rules:
+      - path: $.servers
+        order:
+          - type: alphabetical
+`
+
+func TestParseConfig(t *testing.T) {
+	t.Parallel()
+	// Act
+	config, err := ParseConfig([]byte(configDocument))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, config.Extend)
+	require.Len(t, config.Rules, 2)
+	assert.Equal(t, RuleConfig{Path: "$.info", Order: []OrderConfig{{Type: "simple", Keys: []string{"version", "title"}}}}, config.Rules[0])
+	require.Len(t, config.Overrides, 3)
+	assert.Equal(t, []string{"legacy/**/*.yaml"}, config.Overrides[0].Files)
+}
+
+func TestParseConfig_Errors(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Config   string
+		Expected string
+	}{
+		"unknown field": {
+			Config:   "rule: []\n",
+			Expected: "yaml: unmarshal errors:\n  line 1: field rule not found in type yamlfmt.Config",
+		},
+		"unknown order": {
+			Config:   "rules:\n  - path: $\n    order:\n      - type: reverse\n",
+			Expected: "rule \"$\": \"reverse\": unknown order type",
+		},
+		"invalid chomping": {
+			Config:   "rules:\n  - path: $\n    order:\n      - type: folded\n        chomping: eat\n",
+			Expected: "rule \"$\": invalid chomping \"eat\", should be strip, clip or keep",
+		},
+		"invalid path": {
+			Config:   "rules:\n  - path: $[[\n",
+			Expected: "invalid path \"$[[\": char \"[\": illegal token",
+		},
+		"unknown preset": {
+			Config:   "preset: helm\n",
+			Expected: "\"helm\": unknown preset",
+		},
+		"unknown extensions": {
+			Config:   "extensions: middle\n",
+			Expected: "\"middle\": unknown extensions placement, should be start or end",
+		},
+		"invalid glob": {
+			Config:   "overrides:\n  - files: [\"[a\"]\n",
+			Expected: "invalid glob \"[a\": unterminated character class",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			_, err := ParseConfig([]byte(test.Config))
+
+			// Assert
+			require.EqualError(t, err, test.Expected)
+		})
+	}
+}
+
+func TestParseConfig_SchemaAndOpaque(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	config, err := ParseConfig([]byte("extend: false\nrules:\n  - path: $.definitions[*]\n    schema: true\n  - path: .example\n    opaque: true\n"))
+	require.NoError(t, err)
+
+	// Act
+	rules, err := config.RulesFor("schema.yaml")
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.True(t, rules[0].Schema)
+	assert.True(t, rules[1].Opaque)
+}
+
+func TestParseConfig_Empty(t *testing.T) {
+	t.Parallel()
+	// Act
+	config, err := ParseConfig(nil)
+
+	// Assert
+	require.NoError(t, err)
+	rules, err := config.RulesFor("openapi.yaml")
+	require.NoError(t, err)
+	assert.Len(t, rules, len(DefaultOpenAPIRules()))
+}
+
+func TestConfig_RulesFor_Preset(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	config, err := ParseConfig([]byte("preset: kubernetes\nrules:\n  - path: $.data\n    order:\n      - type: alphabetical\n"))
+	require.NoError(t, err)
+
+	// Act
+	rules, err := config.RulesFor("deployment.yaml")
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, rules, len(DefaultKubernetesRules())+1)
+	assert.Equal(t, "$.data", rules[len(rules)-1].Path)
+}
+
+func TestConfig_RulesFor_Extensions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	config, err := ParseConfig([]byte("extensions: start\n"))
+	require.NoError(t, err)
+
+	// Act
+	rules, err := config.RulesFor("openapi.yaml")
+
+	// Assert
+	require.NoError(t, err)
+	for _, rule := range rules {
+		assert.Contains(t, []Extensions{"", ExtensionsStart}, rule.Extensions, rule.Path)
+	}
+}
+
+func TestConfig_RulesFor(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	dir := t.TempDir()
+	path := filepath.Join(dir, ConfigFile)
+	require.NoError(t, os.WriteFile(path, []byte(configDocument), 0o600))
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+	defaults := len(DefaultOpenAPIRules())
+
+	tests := map[string]struct {
+		File  string
+		Paths []string
+	}{
+		"extends defaults": {
+			File:  "specs/api.yaml",
+			Paths: []string{"$.info", ".description"},
+		},
+		"matches name": {
+			File:  "specs/openapi.yaml",
+			Paths: []string{"$.info", ".description", "$.tags"},
+		},
+		"negated character class": {
+			File:  "v2/api.yaml",
+			Paths: []string{"$.info", ".description", "$.servers"},
+		},
+		"excluded by negated character class": {
+			File:  "v1/api.yaml",
+			Paths: []string{"$.info", ".description"},
+		},
+		"replaces for legacy": {
+			File:  "legacy/v1/openapi.yaml",
+			Paths: []string{"$", "$.tags"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			rules, rulesErr := config.RulesFor(filepath.Join(dir, test.File))
+
+			// Assert
+			require.NoError(t, rulesErr)
+			var paths []string
+			for i, rule := range rules {
+				if len(rules) > defaults && i < defaults {
+					continue // skip the defaults
+				}
+				paths = append(paths, rule.Path)
+			}
+			assert.Equal(t, test.Paths, paths)
+		})
+	}
+}
+
+func TestConfig_RulesFor_Lint(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	config, err := ParseConfig([]byte(configDocument))
+	require.NoError(t, err)
+	rules, err := config.RulesFor("api.yaml")
+	require.NoError(t, err)
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte("info:\n  title: My API\n  version: \"1.0\"\n  description: \"first\\nsecond\"\n"), node))
+
+	// Act
+	Lint(node, rules)
+
+	// Assert
+	info := node.Content[0].Content[1]
+	assert.Equal(t, "version", info.Content[0].Value)
+	assert.Equal(t, "title", info.Content[2].Value)
+	assert.Equal(t, yaml.LiteralStyle, info.Content[5].Style)
+}
+
+func TestFindConfig(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "a", "b"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ConfigFile), nil, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a", "b", "openapi.yaml"), nil, 0o600))
+
+	tests := map[string]string{
+		"file":         filepath.Join(dir, "a", "b", "openapi.yaml"),
+		"directory":    filepath.Join(dir, "a"),
+		"missing file": filepath.Join(dir, "a", "b", "stdin.yaml"),
+	}
+	for name, path := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			found, findErr := FindConfig(path)
+
+			// Assert
+			require.NoError(t, findErr)
+			assert.Equal(t, filepath.Join(dir, ConfigFile), found)
+		})
+	}
+}
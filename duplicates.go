@@ -0,0 +1,159 @@
+package yamlfmt
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Duplicate is a group of keys in a single yaml.MappingNode that are equal, or equal under the case-insensitive
+// comparison used when matching Rule paths (e.g. 'Get' and 'get')
+type Duplicate struct {
+	// Path of the yaml.MappingNode that contains the keys
+	Path string
+	// Keys as written in the document, in document order
+	Keys []string
+	// Lines of the Keys in the source document
+	Lines []int
+	// CaseInsensitive is true iff not all Keys are exactly equal
+	CaseInsensitive bool
+}
+
+// Resolution strategy used by ResolveDuplicates
+type Resolution int
+
+const (
+	// KeepFirst keeps the first key=value pair and removes all later duplicates
+	KeepFirst Resolution = iota
+	// KeepLast keeps the last key=value pair and removes all earlier duplicates
+	KeepLast
+	// Merge deep merges the values of all duplicates into the first key where later values take precedence. If not
+	// all values are yaml.MappingNode the last value is kept
+	Merge
+)
+
+// Duplicates reports every group of duplicate keys in the node, in document order. yaml.v3 does not reject duplicate
+// keys when decoding into a yaml.Node, these would otherwise silently be written back
+func Duplicates(node *yaml.Node) []Duplicate {
+	if node == nil {
+		return nil
+	}
+
+	path := ""
+	if node.Kind == yaml.DocumentNode {
+		path = root
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+
+	var res []Duplicate
+	duplicates(path, node, &res)
+
+	return res
+}
+
+// duplicates recursively reports duplicate keys of node and its children, see Duplicates
+func duplicates(path string, node *yaml.Node, res *[]Duplicate) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for _, group := range groupKeys(node, true) {
+			if len(group) < 2 { //nolint:mnd // a duplicate requires at least two keys
+				continue
+			}
+
+			duplicate := Duplicate{Path: path}
+			for _, i := range group {
+				duplicate.Keys = append(duplicate.Keys, node.Content[i].Value)
+				duplicate.Lines = append(duplicate.Lines, node.Content[i].Line)
+				duplicate.CaseInsensitive = duplicate.CaseInsensitive || node.Content[i].Value != duplicate.Keys[0]
+			}
+			*res = append(*res, duplicate)
+		}
+
+	case yaml.DocumentNode, yaml.SequenceNode, yaml.ScalarNode, yaml.AliasNode:
+	}
+
+	for _, c := range children(path, node) {
+		duplicates(c.Path, c.Node, res)
+	}
+}
+
+// ResolveDuplicates removes duplicate keys from the node (recursively) using the Resolution strategy. If foldCase is
+// true keys that only differ in case are treated as duplicates as well, e.g. 'Get' and 'get'
+func ResolveDuplicates(node *yaml.Node, resolution Resolution, foldCase bool) {
+	if node == nil {
+		return
+	}
+
+	if node.Kind == yaml.MappingNode {
+		groups := groupKeys(node, foldCase)
+		if resolution == KeepLast { // the remaining key takes the position of the last duplicate
+			slices.SortFunc(groups, func(e []int, e2 []int) int {
+				return cmp.Compare(e[len(e)-1], e2[len(e2)-1])
+			})
+		}
+
+		content := make([]*yaml.Node, 0, len(groups)*2) //nolint:mnd // 2 denotes that a key=value pair is two yaml.Node's
+		for _, group := range groups {
+			first := group[0]
+			last := group[len(group)-1]
+			switch resolution {
+			case KeepFirst:
+				content = append(content, node.Content[first], node.Content[first+1])
+			case KeepLast:
+				content = append(content, node.Content[last], node.Content[last+1])
+			case Merge:
+				value := node.Content[first+1]
+				for _, i := range group[1:] {
+					value = merge(value, node.Content[i+1])
+				}
+				content = append(content, node.Content[first], value)
+			}
+		}
+
+		node.Content = content
+	}
+
+	for _, child := range node.Content {
+		ResolveDuplicates(child, resolution, foldCase)
+	}
+}
+
+// groupKeys of a yaml.MappingNode in order of first occurrence, each group contains the yaml.Node.Content indexes of
+// the keys that are equal (or equal ignoring case if foldCase is true)
+func groupKeys(node *yaml.Node, foldCase bool) [][]int {
+	var groups [][]int
+	index := map[string]int{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if foldCase {
+			key = strings.ToLower(key)
+		}
+
+		group, ok := index[key]
+		if !ok {
+			group = len(groups)
+			index[key] = group
+			groups = append(groups, nil)
+		}
+		groups[group] = append(groups[group], i)
+	}
+
+	return groups
+}
+
+// merge src into dst if both are a yaml.MappingNode by appending the key=value pairs of src, these are resolved when
+// ResolveDuplicates descends into the merged node. Otherwise src replaces dst
+func merge(dst *yaml.Node, src *yaml.Node) *yaml.Node {
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		return src
+	}
+
+	dst.Content = append(dst.Content, src.Content...)
+
+	return dst
+}
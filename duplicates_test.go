@@ -0,0 +1,96 @@
+package yamlfmt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// duplicatesDocument contains an exact duplicate path (differing in quoting) and case-insensitively colliding operations
+const duplicatesDocument = `paths:
+  /users:
+    get:
+      summary: first
+      operationId: getUsers
+    Get:
+      summary: second
+  "/users":
+    post:
+      summary: third
+`
+
+func TestDuplicates(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte(duplicatesDocument), node))
+
+	// Act
+	duplicates := Duplicates(node)
+
+	// Assert
+	assert.Equal(t, []Duplicate{
+		{Path: "$.paths", Keys: []string{"/users", "/users"}, Lines: []int{2, 8}},
+		{Path: "$.paths./users", Keys: []string{"get", "Get"}, Lines: []int{3, 6}, CaseInsensitive: true},
+	}, duplicates)
+}
+
+func TestDuplicates_Nil(t *testing.T) {
+	t.Parallel()
+	// Act
+	duplicates := Duplicates(nil)
+
+	// Assert
+	assert.Empty(t, duplicates)
+}
+
+func TestResolveDuplicates(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Resolution Resolution
+		FoldCase   bool
+		Expected   string
+	}{
+		"keep first": {
+			Resolution: KeepFirst,
+			Expected:   "paths:\n  /users:\n    get:\n      summary: first\n      operationId: getUsers\n    Get:\n      summary: second\n",
+		},
+		"keep last": {
+			Resolution: KeepLast,
+			Expected:   "paths:\n  \"/users\":\n    post:\n      summary: third\n",
+		},
+		"merge": {
+			Resolution: Merge,
+			Expected:   "paths:\n  /users:\n    get:\n      summary: first\n      operationId: getUsers\n    Get:\n      summary: second\n    post:\n      summary: third\n",
+		},
+		"merge with fold case": {
+			Resolution: Merge,
+			FoldCase:   true,
+			Expected:   "paths:\n  /users:\n    get:\n      summary: second\n      operationId: getUsers\n    post:\n      summary: third\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			node := new(yaml.Node)
+			require.NoError(t, yaml.Unmarshal([]byte(duplicatesDocument), node))
+
+			// Act
+			ResolveDuplicates(node, test.Resolution, test.FoldCase)
+
+			// Assert
+			writer := new(bytes.Buffer)
+			encoder := yaml.NewEncoder(writer)
+			encoder.SetIndent(whitespace)
+			require.NoError(t, encoder.Encode(node))
+			assert.Equal(t, test.Expected, writer.String())
+			for _, duplicate := range Duplicates(node) {
+				assert.True(t, duplicate.CaseInsensitive && !test.FoldCase)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package yamlfmt
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNodeNotFound is returned when there is no node at a path
+var ErrNodeNotFound = errors.New("node not found")
+
+// Explanation of a Rule that matched the node of Explain
+type Explanation struct {
+	// Rule is the Rule.Path of the Rule
+	Rule string
+	// Index of the Rule in the rules (or of the Rule that declares the nested Rule), i.e. the rules are applied in the
+	// order of their Index
+	Index int
+	// Functions are the names of the Rule.Functions, see FuncName
+	Functions []string
+	// Before are the keys (or items) of the node before the Rule was applied, see Violation.Actual
+	Before []string
+	// After are the keys (or items) of the node after the Rule was applied, see Violation.Expected
+	After []string
+}
+
+// Changed returns true iff the Rule changed the order of the node
+func (e Explanation) Changed() bool {
+	return !slices.Equal(e.Before, e.After)
+}
+
+// Explain which rules match the node at the (canonical) path, e.g. '$.paths./users.get', in the order they are
+// applied by Lint without mutating the node. ErrNodeNotFound is returned if the document has no node at the path
+func Explain(node *yaml.Node, rules []Rule, path string) ([]Explanation, error) {
+	if _, err := parts(path); err != nil {
+		return nil, err
+	}
+
+	node = clone(node, map[*yaml.Node]*yaml.Node{})
+	if lookup(node, path) == nil {
+		return nil, fmt.Errorf("%q: %w", path, ErrNodeNotFound)
+	}
+
+	res := []Explanation{}
+	walk(node, rules, func(rule *Rule, i int, key string, value *yaml.Node) {
+		if !strings.EqualFold(key, path) {
+			rule.Run(key, value)
+			return
+		}
+
+		before := slices.Clone(value.Content)
+		rule.Run(key, value)
+
+		explanation := Explanation{
+			Rule:   rule.Path,
+			Index:  i,
+			Before: labels(&yaml.Node{Kind: value.Kind, Content: before}, before),
+			After:  labels(value, before),
+		}
+		for _, fn := range rule.Functions {
+			explanation.Functions = append(explanation.Functions, FuncName(fn))
+		}
+		res = append(res, explanation)
+	})
+
+	return res, nil
+}
+
+// funcSuffix matches the suffix of the name of a closure, e.g. '.func1'
+var funcSuffix = regexp.MustCompile(`(\.func\d+)+$`)
+
+// FuncName returns the name of the OrderFn without its package, closures are named after the function that
+// returned them, e.g. 'NewSimpleOrdering'
+func FuncName(fn OrderFn) string {
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if f == nil {
+		return "unknown"
+	}
+
+	name := funcSuffix.ReplaceAllString(f.Name(), "")
+	name = name[strings.LastIndex(name, "/")+1:]
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return name
+}
+
+// lookup the node at the canonical path (as walk would reach it), nil if there is none
+func lookup(node *yaml.Node, path string) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+
+	var cursor string
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		cursor = root
+		node = node.Content[0]
+	}
+
+	queue := []child{{Path: cursor, Node: node}}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if strings.EqualFold(c.Path, path) {
+			return c.Node
+		}
+
+		if len(c.Path) < len(path) {
+			queue = append(queue, children(c.Path, c.Node)...)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package yamlfmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	b := []byte("paths:\n  /users:\n    get:\n      responses: {}\n      operationId: getUsers\n      parameters: []\n")
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal(b, node))
+	rules := []Rule{
+		NewRule("$", StringOrderingFn),
+		NewRule("$.paths[*].get", StringOrderingFn),
+		NewRule("$.paths[*].post", StringOrderingFn),
+		NewRule(".get", NewSimpleOrdering("responses")),
+	}
+
+	// Act
+	explanations, err := Explain(node, rules, "$.paths./users.get")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []Explanation{
+		{
+			Rule:      "$.paths[*].get",
+			Index:     1,
+			Functions: []string{"StringOrderingFn"},
+			Before:    []string{"responses", "operationId", "parameters"},
+			After:     []string{"operationId", "parameters", "responses"},
+		},
+		{
+			Rule:      ".get",
+			Index:     3,
+			Functions: []string{"NewSimpleOrdering"},
+			Before:    []string{"operationId", "parameters", "responses"},
+			After:     []string{"responses", "operationId", "parameters"},
+		},
+	}, explanations)
+	assert.True(t, explanations[0].Changed())
+	assert.Equal(t, "responses", node.Content[0].Content[1].Content[1].Content[1].Content[0].Value, "the node is not mutated")
+}
+
+func TestExplain_NoMatchingRules(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte("info:\n  title: API\n"), node))
+
+	// Act
+	explanations, err := Explain(node, []Rule{NewRule("$", StringOrderingFn)}, "$.info")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, explanations)
+}
+
+func TestExplain_Errors(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Path     string
+		Expected string
+	}{
+		"not found": {
+			Path:     "$.paths",
+			Expected: "\"$.paths\": node not found",
+		},
+		"invalid path": {
+			Path:     "$[[",
+			Expected: "invalid path \"$[[\": char \"[\": illegal token",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			node := new(yaml.Node)
+			require.NoError(t, yaml.Unmarshal([]byte("info: {}\n"), node))
+
+			// Act
+			_, err := Explain(node, []Rule{NewRule("$", StringOrderingFn)}, test.Path)
+
+			// Assert
+			require.EqualError(t, err, test.Expected)
+		})
+	}
+}
+
+func TestFuncName(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Fn       OrderFn
+		Expected string
+	}{
+		"function": {
+			Fn:       StringOrderingFn,
+			Expected: "StringOrderingFn",
+		},
+		"closure": {
+			Fn:       NewSimpleOrdering("a"),
+			Expected: "NewSimpleOrdering",
+		},
+		"block scalar styling": {
+			Fn:       NewBlockScalarStyling(yaml.LiteralStyle, 0, ChompAny),
+			Expected: "NewBlockScalarStyling",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			actual := FuncName(test.Fn)
+
+			// Assert
+			assert.Equal(t, test.Expected, actual)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package yamlfmt
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Extensions is the placement of the specification extensions (the keys that start with 'x-') of an object
+type Extensions string
+
+// placements of the Extensions
+const (
+	ExtensionsStart Extensions = "start"
+	ExtensionsEnd   Extensions = "end"
+)
+
+// extensionPrefix is the prefix of the key of a specification extension
+const extensionPrefix = "x-"
+
+// ErrUnknownExtensions is returned when the placement of the Extensions is not supported
+var ErrUnknownExtensions = errors.New("unknown extensions placement, should be start or end")
+
+// ParseExtensions parses the placement of the Extensions
+func ParseExtensions(s string) (Extensions, error) {
+	switch extensions := Extensions(s); extensions {
+	case ExtensionsStart, ExtensionsEnd:
+		return extensions, nil
+	}
+
+	return "", fmt.Errorf("%q: %w", s, ErrUnknownExtensions)
+}
+
+// NewExtensionOrdering groups the keys of a yaml.MappingNode that start with 'x-' at the start or end (placement) and
+// sorts them alphabetically, the order of the other keys is preserved
+func NewExtensionOrdering(placement Extensions) OrderFn {
+	return func(_ string, value *yaml.Node) {
+		if value == nil || len(value.Content) == 0 || value.Kind != yaml.MappingNode {
+			return
+		}
+
+		type Pair struct {
+			Key   *yaml.Node
+			Value *yaml.Node
+		}
+
+		var keys, extensions []Pair
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			pair := Pair{Key: value.Content[i], Value: value.Content[i+1]}
+			if strings.HasPrefix(pair.Key.Value, extensionPrefix) {
+				extensions = append(extensions, pair)
+				continue
+			}
+			keys = append(keys, pair)
+		}
+
+		slices.SortStableFunc(extensions, func(e Pair, e2 Pair) int {
+			return cmp.Compare(e.Key.Value, e2.Key.Value)
+		})
+
+		sorted := append(keys, extensions...)
+		if placement == ExtensionsStart {
+			sorted = append(extensions, keys...)
+		}
+		for i, pair := range sorted {
+			value.Content[i*2] = pair.Key
+			value.Content[i*2+1] = pair.Value
+		}
+	}
+}
+
+// newObjectRule creates a Rule for the objects of a specification at path: the keys are sorted alphabetically, then
+// by the fns and the Extensions are placed at the end
+func newObjectRule(path string, fns ...OrderFn) Rule {
+	return Rule{Path: path, Functions: append([]OrderFn{StringOrderingFn}, fns...), Extensions: ExtensionsEnd}
+}
+
+// WithExtensions returns a copy of the rules where every Rule (or nested Rule) that places the Extensions of an object
+// places them at the placement instead
+func WithExtensions(rules []Rule, placement Extensions) []Rule {
+	res := slices.Clone(rules)
+	for i := range res {
+		if res[i].Extensions != "" {
+			res[i].Extensions = placement
+		}
+		if res[i].Rules != nil {
+			res[i].Rules = WithExtensions(res[i].Rules, placement)
+		}
+	}
+
+	return res
+}
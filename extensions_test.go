@@ -0,0 +1,126 @@
+package yamlfmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseExtensions(t *testing.T) {
+	t.Parallel()
+	// Act
+	start, startErr := ParseExtensions("start")
+	end, endErr := ParseExtensions("end")
+	_, err := ParseExtensions("middle")
+
+	// Assert
+	require.NoError(t, startErr)
+	require.NoError(t, endErr)
+	assert.Equal(t, ExtensionsStart, start)
+	assert.Equal(t, ExtensionsEnd, end)
+	require.EqualError(t, err, "\"middle\": unknown extensions placement, should be start or end")
+}
+
+func TestNewExtensionOrdering(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Placement Extensions
+		Expected  []string
+	}{
+		"start": {
+			Placement: ExtensionsStart,
+			Expected:  []string{"x-a", "x-b", "title", "X-Header", "version"},
+		},
+		"end": {
+			Placement: ExtensionsEnd,
+			Expected:  []string{"title", "X-Header", "version", "x-a", "x-b"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			node := new(yaml.Node)
+			require.NoError(t, yaml.Unmarshal([]byte("title: a\nx-b: 1\nX-Header: 2\nx-a: 3\nversion: b\n"), node))
+			value := node.Content[0]
+
+			// Act
+			NewExtensionOrdering(test.Placement)("$", value)
+
+			// Assert
+			assert.Equal(t, test.Expected, labels(value, value.Content))
+		})
+	}
+}
+
+func TestNewExtensionOrdering_NotAMapping(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{{Kind: yaml.ScalarNode, Value: "x-a"}}}
+
+	// Act
+	NewExtensionOrdering(ExtensionsStart)("$", node)
+
+	// Assert
+	assert.Equal(t, "x-a", node.Content[0].Value)
+}
+
+func TestWithExtensions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte(`openapi: "3.0.0"
+x-logo: logo.png
+info:
+  x-audience: internal
+  version: "1.0"
+  title: My API
+paths:
+  /data:
+    get:
+      responses:
+        "200":
+          description: Data
+          headers:
+            x-rate-limit:
+              schema:
+                type: integer
+            etag:
+              schema:
+                type: string
+      x-internal: true
+`), node))
+	preset := DefaultOpenAPIRules()
+	rules := WithExtensions(preset, ExtensionsStart)
+
+	// Act
+	Lint(node, rules)
+
+	// Assert
+	b, err := yaml.Marshal(node)
+	require.NoError(t, err)
+	assert.Equal(t, `x-logo: logo.png
+openapi: "3.0.0"
+info:
+    x-audience: internal
+    title: My API
+    version: "1.0"
+paths:
+    /data:
+        get:
+            x-internal: true
+            responses:
+                "200":
+                    description: Data
+                    headers:
+                        etag:
+                            schema:
+                                type: string
+                        x-rate-limit:
+                            schema:
+                                type: integer
+`, string(b))
+	assert.Equal(t, ExtensionsEnd, preset[0].Extensions, "the preset is not changed")
+}
@@ -5,6 +5,7 @@ import (
 	"cmp"
 	"errors"
 	"fmt"
+	"io"
 	"slices"
 	"strconv"
 	"strings"
@@ -21,83 +22,101 @@ const all = "*"
 // whitespace to use when encoding the yaml.Node to []byte
 const whitespace = 2
 
+// Option configures optional behaviour of LintBytes
+type Option func(*options)
+
+// options that can be set with an Option
+type options struct {
+	// yaml11 is non-nil iff QuoteYAML11 should run after Lint
+	yaml11 func(Quoted)
+	// trace is non-nil iff LintWithTrace should be used instead of Lint
+	trace func(Change)
+}
+
+// WithYAML11Quoting runs QuoteYAML11 on the linted document and calls report (if non-nil) for every quoted scalar
+func WithYAML11Quoting(report func(Quoted)) Option {
+	return func(o *options) {
+		o.yaml11 = func(q Quoted) {
+			if report != nil {
+				report(q)
+			}
+		}
+	}
+}
+
+// WithTrace lints with LintWithTrace and calls trace for every node that a Rule matched
+func WithTrace(trace func(Change)) Option {
+	return func(o *options) {
+		o.trace = trace
+	}
+}
+
 // LintBytes is a utility method that unmarshaled the provided bytes into a yaml.Node,
-// applies Lint on the node and returns the marshaled result
-func LintBytes(b []byte, rules []Rule) ([]byte, error) {
+// applies Lint on the node and returns the marshaled result. Every document of a multi-document stream is linted
+func LintBytes(b []byte, rules []Rule, opts ...Option) ([]byte, error) {
 	if len(b) == 0 {
 		return b, nil
 	}
 
-	// unmarshal into yaml.Node
-	node := new(yaml.Node)
-	err := yaml.Unmarshal(b, node)
-	if err != nil {
-		return nil, err
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
 	}
 
-	// lint the node with provided rules
-	Lint(node, rules)
-
-	// encode back into bytes
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
 	writer := new(bytes.Buffer)
 	encoder := yaml.NewEncoder(writer)
 	encoder.SetIndent(whitespace)
-	err = encoder.Encode(node)
-	if err != nil {
-		return nil, err
-	}
-
-	return writer.Bytes(), nil
-}
+	var documents []*yaml.Node
+	for {
+		// unmarshal into yaml.Node
+		node := new(yaml.Node)
+		err := decoder.Decode(node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
 
-// Lint a yaml.Node the provided slice of Rule
-func Lint(node *yaml.Node, rules []Rule) { //nolint:cyclop // accepted
-	if node == nil || len(rules) == 0 {
-		return
-	}
+		// lint the node with provided rules
+		if o.trace != nil {
+			LintWithTrace(node, rules, o.trace)
+		} else {
+			Lint(node, rules)
+		}
 
-	cursor := node
-	var path string
-	if node.Kind == yaml.DocumentNode { // document node, the root path starts with $
-		path = root
+		if o.yaml11 != nil {
+			for _, quoted := range QuoteYAML11(node) {
+				o.yaml11(quoted)
+			}
+		}
 
-		if len(cursor.Content) == 0 {
-			return // nothing to order
+		// encode back into bytes
+		err = encoder.Encode(node)
+		if err != nil {
+			return nil, err
 		}
-		cursor = cursor.Content[0]
+		documents = append(documents, node)
 	}
 
-	for _, rule := range rules {
-		queue := map[string]*yaml.Node{path: cursor}
-		for len(queue) > 0 {
-			// dequeue key=value pair
-			var key string
-			var node *yaml.Node
-			for k, v := range queue {
-				key = k
-				node = v
-				delete(queue, k)
-				break // dequeue
-			}
-
-			// match rule
-			if !rule.contains(key) {
-				continue
-			}
+	err := encoder.Close()
+	if err != nil {
+		return nil, err
+	}
 
-			// add next to queue
-			// note that this is not optimal if a match is final as the last layer will be added
-			// even though it can never match, this is accepted to reduce the complexity of the solution
-			for k, v := range next(key, node) {
-				queue[k] = v
-			}
+	return wrapFolded(writer.Bytes(), documents), nil
+}
 
-			// if match, run Fn
-			if rule.match(key) {
-				rule.Run(key, node)
-			}
-		}
-	}
+// Lint a yaml.Node the provided slice of Rule. The nodes are visited breadth first and on every node the matching
+// rules run in the order of the slice, the children of a node are matched after the rules ran on it. A Rule therefore
+// observes the changes of the preceding rules on the same node and the changes of any Rule on its ancestors, but not
+// the changes that a preceding Rule makes deeper in the document: e.g. with the rules ['$.a[1]', '$.a'] the rule
+// '$.a' sorts the items of 'a' first and '$.a[1]' then matches the item that was moved to index 1
+func Lint(node *yaml.Node, rules []Rule) {
+	walk(node, rules, func(rule *Rule, _ int, path string, value *yaml.Node) {
+		rule.Run(path, value)
+	})
 }
 
 // Validate rules that there are no parse errors
@@ -112,6 +131,13 @@ func Validate(rules []Rule) error {
 		if ruleErr != nil {
 			err = errors.Join(err, ruleErr)
 		}
+
+		for _, nested := range rule.Rules {
+			if strings.HasPrefix(nested.Path, root) {
+				err = errors.Join(err, fmt.Errorf("nested rule %q of %q: %w", nested.Path, rule.Path, ErrAbsoluteNestedRule))
+			}
+		}
+		err = errors.Join(err, Validate(rule.Rules))
 	}
 
 	return err
@@ -131,6 +157,18 @@ type Rule struct {
 	Path string
 	// Functions to execute if there is a Path match
 	Functions []OrderFn
+	// Schema is true if the Path addresses JSON Schemas: the Functions also run on every subschema of a match (e.g.
+	// the 'properties', 'items' or 'allOf' of the schema) at any depth
+	Schema bool
+	// Opaque is true if the nodes at Path are user data (e.g. an 'example'): no Rule matches the nodes or descends
+	// into them and the Functions are never run
+	Opaque bool
+	// Extensions places the specification extensions ('x-' keys) of the matching nodes after the Functions ran, the
+	// extensions are not placed if empty
+	Extensions Extensions
+	// Rules are nested rules with a relative Path that is matched from every node that the Rule matched (for a
+	// Rule.Schema also from every subschema), e.g. '.discriminator' matches the 'discriminator' of the node only
+	Rules []Rule
 }
 
 // NewRule constructor for Rule
@@ -138,11 +176,15 @@ func NewRule(path string, fns ...OrderFn) Rule {
 	return Rule{Path: path, Functions: fns}
 }
 
-// Run Rule.Functions for given key and value
+// Run Rule.Functions for given key and value and place the Rule.Extensions
 func (r *Rule) Run(key string, value *yaml.Node) {
 	for _, fn := range r.Functions {
 		fn(key, value)
 	}
+
+	if r.Extensions != "" {
+		NewExtensionOrdering(r.Extensions)(key, value)
+	}
 }
 
 // contains returns true iff the path is still possible from the Rule.Path
@@ -151,77 +193,69 @@ func (r *Rule) contains(path string) bool {
 		return true // if the match is relative (e.g. '.schema') traverse everything
 	}
 
-	pathParts, pathErr := parts(path)
-	if pathErr != nil {
-		panic(pathErr) // invalid rules supplied, use Validate to catch ahead of time
-	}
-	rulePathParts, rulePathErr := parts(r.Path)
-	if rulePathErr != nil {
-		panic(rulePathErr) // invalid rules supplied, use Validate to catch ahead of  time
-	}
+	pathParts := mustParts(path)
+	return newPattern(r.Path).contains(pathParts)
+}
 
-	// iterate over path parts to check if it matches the rule parts
-	for i, pathPart := range pathParts {
-		if i > len(rulePathParts)-1 {
-			return false // to deep
-		}
+// match returns true iff the Rule.Path matches the provided path
+func (r *Rule) match(path string) bool {
+	pathParts := mustParts(path)
+	return newPattern(r.Path).match(pathParts)
+}
 
-		rulePart := rulePathParts[i]
-		if !strings.EqualFold(pathPart, rulePart) &&
-			!strings.EqualFold(rulePart, all) &&
-			!strings.EqualFold(rulePart, indexOpen+all+indexClose) {
-			return false
-		}
+// pattern is a parsed Rule.Path, walk parses every Rule.Path once instead of for every node
+type pattern struct {
+	parts    []string
+	relative bool
+}
+
+// newPattern parses the Rule.Path, it panics if the path is invalid
+func newPattern(path string) pattern {
+	return pattern{parts: mustParts(path), relative: !strings.HasPrefix(path, root)}
+}
+
+// mustParts returns the parts of the path, it panics if the path is invalid
+func mustParts(path string) []string {
+	res, err := parts(path)
+	if err != nil {
+		panic(err) // invalid rules supplied, use Validate to catch ahead of time
 	}
 
-	return true
+	return res
 }
 
-// match returns true iff the Rule.Path matches the provided path
-func (r *Rule) match(path string) bool { //nolint:cyclop // accepted
-	pathParts, pathErr := parts(path)
-	if pathErr != nil {
-		panic(pathErr) // invalid rules supplied, use Validate to catch ahead of  time
+// contains returns true iff a path with the parts is still possible from the pattern
+func (p pattern) contains(pathParts []string) bool {
+	if p.relative {
+		return true // if the match is relative (e.g. '.schema') traverse everything
 	}
-	rulePathParts, rulePathErr := parts(r.Path)
-	if rulePathErr != nil {
-		panic(rulePathErr) // invalid rules supplied, use Validate to catch ahead of  time
+	if len(pathParts) > len(p.parts) {
+		return false // to deep
 	}
 
-	relative := !strings.HasPrefix(r.Path, root)
-	if !relative && len(pathParts) != len(rulePathParts) {
-		return false
+	for i, pathPart := range pathParts {
+		if !matchPart(p.parts[i], pathPart) {
+			return false
+		}
 	}
 
-	// if relative, go right to left from perspective of rule
-	if relative {
-		for i := len(rulePathParts) - 1; i >= 0; i-- {
-			rulePart := rulePathParts[i]
-			pathPartIndex := (len(pathParts) - 1) - ((len(rulePathParts) - 1) - i)
-			if pathPartIndex > len(pathParts)-1 {
-				return false // path did not match
-			}
-			pathPart := pathParts[pathPartIndex]
-			if !strings.EqualFold(pathPart, rulePart) &&
-				!strings.EqualFold(rulePart, delimiter+all) &&
-				!strings.EqualFold(rulePart, indexOpen+all+indexClose) {
-				return false
-			}
-		}
+	return true
+}
 
-		return true
+// match returns true iff the pattern matches a path with the parts, a relative pattern matches the end of the path
+func (p pattern) match(pathParts []string) bool {
+	var offset int
+	switch {
+	case p.relative && len(pathParts) < len(p.parts):
+		return false // path is shorter than the rule
+	case p.relative:
+		offset = len(pathParts) - len(p.parts)
+	case len(pathParts) != len(p.parts):
+		return false
 	}
 
-	// iterate over path parts to check if it matches the rule parts
-	for i, pathPart := range pathParts {
-		if i > len(rulePathParts)-1 {
-			return false // to deep
-		}
-
-		rulePart := rulePathParts[i]
-		if !strings.EqualFold(pathPart, rulePart) &&
-			!strings.EqualFold(rulePart, delimiter+all) &&
-			!strings.EqualFold(rulePart, indexOpen+all+indexClose) {
+	for i, rulePart := range p.parts {
+		if !matchPart(rulePart, pathParts[offset+i]) {
 			return false
 		}
 	}
@@ -229,6 +263,11 @@ func (r *Rule) match(path string) bool { //nolint:cyclop // accepted
 	return true
 }
 
+// matchPart returns true iff the part of a Rule.Path is equal to the part of a path or a wildcard
+func matchPart(rulePart string, pathPart string) bool {
+	return strings.EqualFold(rulePart, pathPart) || rulePart == delimiter+all || rulePart == indexOpen+all+indexClose
+}
+
 // check if two string parts match
 // func check(rulePart string, pathPart string) bool {
 //	if strings.HasPrefix(rulePart, delimiter) {
@@ -336,6 +375,9 @@ const indexOpen token = "["
 // indexClose desnotes the closure of an array indexing operation
 const indexClose token = "]"
 
+// ErrAbsoluteNestedRule is returned when the Path of a nested Rule (see Rule.Rules) starts with the document root
+var ErrAbsoluteNestedRule = errors.New("the path of a nested rule must be relative")
+
 // ErrIllegalToken is returned when a token is used that is not expected, e.g. two indexOpen tokens [[ sequentially
 var ErrIllegalToken = errors.New("illegal token")
 
@@ -404,24 +446,37 @@ func parts(path string) ([]string, error) {
 // a cursor '$' and a sequence node => '$[0]'
 func next(cursor string, node *yaml.Node) map[string]*yaml.Node {
 	res := map[string]*yaml.Node{}
+	for _, c := range children(cursor, node) {
+		res[c.Path] = c.Node
+	}
+
+	return res
+}
+
+// child is a node with the path it was reached by from some cursor
+type child struct {
+	Path string
+	Node *yaml.Node
+	// part of the Path that was added to the cursor, e.g. '.key' or '[0]'
+	part string
+}
+
+// children returns the same paths as next in document order (including any duplicate keys)
+func children(cursor string, node *yaml.Node) []child {
 	if node == nil {
-		return res
+		return nil
 	}
 
+	var res []child
 	if node.Content != nil && node.Kind == yaml.MappingNode {
-		var key string
-		for i, content := range node.Content {
-			if i%2 == 0 {
-				key = cursor + delimiter + content.Value
-				continue
-			}
-
-			res[key] = content
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			part := delimiter + node.Content[i].Value
+			res = append(res, child{Path: cursor + part, Node: node.Content[i+1], part: part})
 		}
 	} else if node.Content != nil && node.Kind == yaml.SequenceNode {
 		for i, content := range node.Content {
-			key := cursor + indexOpen + strconv.Itoa(i) + indexClose
-			res[key] = content
+			part := indexOpen + strconv.Itoa(i) + indexClose
+			res = append(res, child{Path: cursor + part, Node: content, part: part})
 		}
 	}
 
@@ -23,6 +23,32 @@ func TestLintBytes_NoBytes(t *testing.T) {
 	assert.Equal(t, b, actual)
 }
 
+func TestLintBytes_MultipleDocuments(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	b := []byte("b: 1\na: 2\n---\nd: 3\nc: 4\n")
+
+	// Act
+	actual, err := LintBytes(b, []Rule{NewRule("$", StringOrderingFn)})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "a: 2\nb: 1\n---\nc: 4\nd: 3\n", string(actual))
+}
+
+func TestLintBytes_KeyWithTokens(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	b := []byte("b:\n  d: 1\n  c: 2\na]b:\n  f: 3\n  e: 4\nc.d:\n  h: 5\n  g: 6\n")
+
+	// Act
+	actual, err := LintBytes(b, []Rule{NewRule("$", StringOrderingFn), NewRule("$[*]", StringOrderingFn), NewOpaqueRule("$.c")})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "a]b:\n  e: 4\n  f: 3\nb:\n  c: 2\n  d: 1\nc.d:\n  g: 6\n  h: 5\n", string(actual)) // $.c is not $.c.d
+}
+
 func TestLint_DocumentWithoutContent(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -98,6 +124,19 @@ func TestValidate_Errors(t *testing.T) {
 	require.EqualError(t, err, "invalid path \"$[[\": char \"[\": illegal token")
 }
 
+func TestValidate_NestedRules(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	rule := Rule{Path: ".schema", Rules: []Rule{NewRule("$.xml"), NewRule(".xml[[")}}
+
+	// Act
+	err := Validate([]Rule{rule})
+
+	// Assert
+	require.ErrorIs(t, err, ErrAbsoluteNestedRule)
+	require.ErrorIs(t, err, ErrIllegalToken)
+}
+
 func TestValidate_NoErrors(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -300,6 +339,19 @@ func TestMatch_AbsolutePathDifferentParts(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestMatch_RelativeRuleLongerThanPath(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	path := "$.containers"
+	rule := NewRule(".spec.containers[*]")
+
+	// Act
+	ok := rule.match(path)
+
+	// Assert
+	assert.False(t, ok)
+}
+
 func TestMatch_RulePathError(t *testing.T) {
 	t.Parallel()
 	// Arrange
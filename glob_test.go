@@ -0,0 +1,48 @@
+package yamlfmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobRegexp(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Glob     string
+		Path     string
+		Expected bool
+	}{
+		"star":                     {Glob: "*.yaml", Path: "api.yaml", Expected: true},
+		"star within a directory":  {Glob: "*.yaml", Path: "specs/api.yaml", Expected: false},
+		"double star":              {Glob: "**/*.yaml", Path: "specs/v1/api.yaml", Expected: true},
+		"double star without dirs": {Glob: "**/*.yaml", Path: "api.yaml", Expected: true},
+		"question mark":            {Glob: "v?.yaml", Path: "v1.yaml", Expected: true},
+		"character class":          {Glob: "v[12].yaml", Path: "v2.yaml", Expected: true},
+		"negated character class":  {Glob: "v[!1].yaml", Path: "v2.yaml", Expected: true},
+		"negated class excludes":   {Glob: "v[!1].yaml", Path: "v1.yaml", Expected: false},
+		"leading slash":            {Glob: "/specs/*.yaml", Path: "specs/api.yaml", Expected: true},
+		"regexp meta characters":   {Glob: "a+b.yaml", Path: "aab.yaml", Expected: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			re, err := GlobRegexp(test.Glob)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, re.MatchString(test.Path))
+		})
+	}
+}
+
+func TestGlobRegexp_UnterminatedClass(t *testing.T) {
+	t.Parallel()
+	// Act
+	_, err := GlobRegexp("[a-z")
+
+	// Assert
+	require.EqualError(t, err, "invalid glob \"[a-z\": unterminated character class")
+}
@@ -0,0 +1,17 @@
+package yamlfmt
+
+// NewOpaqueRule constructor for a Rule that declares an opaque zone, see Rule.Opaque
+func NewOpaqueRule(path string) Rule {
+	return Rule{Path: path, Opaque: true}
+}
+
+// opaque returns true iff the parts of a path match one of the zones
+func opaque(zones []pattern, pathParts []string) bool {
+	for _, zone := range zones {
+		if zone.match(pathParts) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,105 @@
+package yamlfmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestOpaque(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Path     string
+		Expected bool
+	}{
+		"zone": {
+			Path:     "$.a.example",
+			Expected: true,
+		},
+		"below a zone": {
+			Path:     "$.a.example.b",
+			Expected: false, // walk does not reach the nodes below a zone
+		},
+		"no zone": {
+			Path:     "$.b",
+			Expected: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			zones := []pattern{newPattern(".example")}
+
+			// Act
+			ok := opaque(zones, mustParts(test.Path))
+
+			// Assert
+			assert.Equal(t, test.Expected, ok)
+		})
+	}
+}
+
+func TestLint_OpaqueRule(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte(`schema:
+  type: object
+  example:
+    schema:
+      b: 2
+      a: 1
+`), node))
+	rules := []Rule{NewSchemaRule(".schema", StringOrderingFn), NewOpaqueRule(".example")}
+	var paths []string
+
+	// Act
+	LintWithTrace(node, rules, func(change Change) {
+		paths = append(paths, change.Path)
+	})
+
+	// Assert
+	assert.Equal(t, []string{"$.schema"}, paths)
+	b, err := yaml.Marshal(node)
+	require.NoError(t, err)
+	assert.Equal(t, `schema:
+    example:
+        schema:
+            b: 2
+            a: 1
+    type: object
+`, string(b))
+}
+
+func TestLint_OpaqueSubschema(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte(`components:
+  schemas:
+    Pet:
+      properties:
+        data:
+          zeta: 1
+          alpha: 2
+`), node))
+	rules := []Rule{NewOpaqueRule("$.components.schemas.Pet.properties"), NewSchemaRule("$.components.schemas[*]", StringOrderingFn)}
+
+	// Act
+	Lint(node, rules)
+
+	// Assert
+	b, err := yaml.Marshal(node)
+	require.NoError(t, err)
+	assert.Equal(t, `components:
+    schemas:
+        Pet:
+            properties:
+                data:
+                    zeta: 1
+                    alpha: 2
+`, string(b))
+}
@@ -3,12 +3,13 @@ module github.com/Emptyless/yamlfmt/openapi-fmt
 go 1.24.1
 
 require (
-	github.com/Emptyless/yamlfmt v0.1.0
-	github.com/spf13/cobra v1.9.1
-	gopkg.in/yaml.v3 v3.0.1
+	github.com/Emptyless/yamlfmt v0.2.0
+	github.com/Emptyless/yamlfmt/cmd/yamlfmt v0.2.0
 )
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/spf13/cobra v1.9.1 // indirect
 	github.com/spf13/pflag v1.0.6 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,105 @@
+package yamlfmt
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PathOrderingFn sorts the keys of a yaml.MappingNode as the paths of a Paths Object: segment by segment, where a
+// path is directly followed by its sub-paths and a literal segment comes before a templated segment (e.g. '/users',
+// '/users/me', '/users/{id}', '/users/{id}/posts'), which is the precedence of most routers
+func PathOrderingFn(_ string, value *yaml.Node) {
+	sortKeys(value, comparePaths)
+}
+
+// comparePaths compares the paths segment by segment, a path is before the paths that it is a prefix of
+func comparePaths(a string, b string) int {
+	aSegments := strings.Split(strings.TrimPrefix(a, "/"), "/")
+	bSegments := strings.Split(strings.TrimPrefix(b, "/"), "/")
+	for i := 0; i < len(aSegments) && i < len(bSegments); i++ {
+		aTemplated, bTemplated := strings.Contains(aSegments[i], "{"), strings.Contains(bSegments[i], "{")
+		switch {
+		case aTemplated && !bTemplated:
+			return 1 // literal segments first
+		case !aTemplated && bTemplated:
+			return -1
+		}
+
+		if c := cmp.Compare(aSegments[i], bSegments[i]); c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Or(cmp.Compare(len(aSegments), len(bSegments)), cmp.Compare(a, b))
+}
+
+// ResponseOrderingFn sorts the keys of a yaml.MappingNode as the status codes of a Responses Object: numeric
+// ascending, every range (e.g. '2XX') after the codes it contains, other keys after the codes and 'default' last. The
+// keys are compared by value, so a quoted '200' and a plain 200 are the same code
+func ResponseOrderingFn(_ string, value *yaml.Node) {
+	sortKeys(value, func(a string, b string) int {
+		return cmp.Or(compareResponses(responseRank(a), responseRank(b)), cmp.Compare(a, b))
+	})
+}
+
+// ranks of the keys of a Responses Object that are not a code, after the ranges '1XX' to '5XX'
+const (
+	responseOtherRank   = 10
+	responseDefaultRank = 11
+)
+
+// responseCodes is the number of codes in a range, e.g. '2XX' contains 200 to 299
+const responseCodes = 100
+
+// responseRank of a key of a Responses Object: the range and the position within the range, e.g. [2, 0] for '200'
+// and [2, 100] for '2XX'
+func responseRank(key string) [2]int {
+	switch {
+	case strings.EqualFold(key, "default"):
+		return [2]int{responseDefaultRank, 0}
+	case len(key) == 3 && strings.EqualFold(key[1:], "XX") && key[0] >= '1' && key[0] <= '5':
+		return [2]int{int(key[0] - '0'), responseCodes}
+	}
+
+	code, err := strconv.Atoi(key)
+	if err != nil || code < responseCodes || code >= 6*responseCodes {
+		return [2]int{responseOtherRank, 0}
+	}
+
+	return [2]int{code / responseCodes, code % responseCodes}
+}
+
+// compareResponses compares the ranks of two keys of a Responses Object
+func compareResponses(a [2]int, b [2]int) int {
+	return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+}
+
+// sortKeys sorts the key=value pairs of a yaml.MappingNode with the compare function of the keys
+func sortKeys(value *yaml.Node, compare func(a string, b string) int) {
+	if value == nil || len(value.Content) == 0 || value.Kind != yaml.MappingNode {
+		return
+	}
+
+	type Pair struct {
+		Key   *yaml.Node
+		Value *yaml.Node
+	}
+
+	nodes := make([]Pair, 0, len(value.Content)/2) //nolint:mnd // 2 denotes that a key=value pair is two yaml.Node's
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		nodes = append(nodes, Pair{Key: value.Content[i], Value: value.Content[i+1]})
+	}
+
+	slices.SortStableFunc(nodes, func(e Pair, e2 Pair) int {
+		return compare(e.Key.Value, e2.Key.Value)
+	})
+
+	for i, pair := range nodes {
+		value.Content[i*2] = pair.Key
+		value.Content[i*2+1] = pair.Value
+	}
+}
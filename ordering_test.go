@@ -0,0 +1,99 @@
+package yamlfmt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPathOrderingFn(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	paths := []string{"/users/{id}/posts", "/users/{id}", "/users", "/orders", "/users/me", "/users/v{version}", "/users/me/settings", "/", "/users/{id}/avatar", "x-paths"}
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte(strings.Join(paths, ": {}\n")+": {}\n"), node))
+	value := node.Content[0]
+
+	// Act
+	PathOrderingFn("$.paths", value)
+
+	// Assert
+	expected := []string{"/", "/orders", "/users", "/users/me", "/users/me/settings", "/users/v{version}", "/users/{id}", "/users/{id}/avatar", "/users/{id}/posts", "x-paths"}
+	assert.Equal(t, expected, labels(value, value.Content))
+}
+
+func TestComparePaths(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		A        string
+		B        string
+		Expected int
+	}{
+		"literal before template":  {A: "/users/me", B: "/users/{id}", Expected: -1},
+		"template after literal":   {A: "/users/{id}", B: "/users/me", Expected: 1},
+		"parent before child":      {A: "/users", B: "/users/{id}", Expected: -1},
+		"sub-paths before sibling": {A: "/users/{id}/posts", B: "/users_v2", Expected: -1},
+		"trailing slash after":     {A: "/users", B: "/users/", Expected: -1},
+		"equal":                    {A: "/users/{id}", B: "/users/{id}", Expected: 0},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			res := comparePaths(test.A, test.B)
+
+			// Assert
+			assert.Equal(t, test.Expected, res)
+		})
+	}
+}
+
+func TestResponseOrderingFn(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte(`default: {}
+"404": {}
+2XX: {}
+4xx: {}
+201: {}
+"200": {}
+x-codes: {}
+500: {}
+"400": {}
+`), node))
+	value := node.Content[0]
+
+	// Act
+	ResponseOrderingFn("$.paths./data.get.responses", value)
+
+	// Assert
+	assert.Equal(t, []string{"200", "201", "2XX", "400", "404", "4xx", "500", "x-codes", "default"}, labels(value, value.Content))
+}
+
+func TestResponseRank(t *testing.T) {
+	t.Parallel()
+	tests := map[string][2]int{
+		"200":     {2, 0},
+		"204":     {2, 4},
+		"2XX":     {2, 100},
+		"5xx":     {5, 100},
+		"6XX":     {10, 0},
+		"99":      {10, 0},
+		"x-codes": {10, 0},
+		"default": {11, 0},
+	}
+	for key, expected := range tests {
+		t.Run(key, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			res := responseRank(key)
+
+			// Assert
+			assert.Equal(t, expected, res)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package yamlfmt
+
+import (
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NodePath is the canonical path of a node in a document, see Paths
+type NodePath struct {
+	// Path of the node as it is matched against Rule.Path, e.g. '$.paths./users.get.responses.200'
+	Path string
+	// Kind of the node
+	Kind yaml.Kind
+	// Line of the node in the source document
+	Line int
+	// Column of the node in the source document
+	Column int
+	// Addressable is false if no Rule.Path selects exactly this node, i.e. the path of the node (or one of its
+	// parents) has a key that contains a '.', '[' or ']' which is not escaped, or a key '*' which is a wildcard
+	Addressable bool
+}
+
+// Paths of every node of the document in document order, i.e. the paths a Rule.Path is matched against
+func Paths(node *yaml.Node) []NodePath {
+	if node == nil {
+		return []NodePath{}
+	}
+
+	var path string
+	if node.Kind == yaml.DocumentNode { // document node, the root path starts with $
+		path = root
+		if len(node.Content) == 0 {
+			return []NodePath{}
+		}
+		node = node.Content[0]
+	}
+
+	return nodePaths([]NodePath{}, path, node, true)
+}
+
+// nodePaths appends the NodePath of node and its children to res
+func nodePaths(res []NodePath, path string, node *yaml.Node, addressable bool) []NodePath {
+	res = append(res, NodePath{Path: path, Kind: node.Kind, Line: node.Line, Column: node.Column, Addressable: addressable})
+	for _, c := range children(path, node) {
+		res = nodePaths(res, c.Path, c.Node, addressable && segmentAddressable(c.Path[len(path):]))
+	}
+
+	return res
+}
+
+// segmentAddressable returns true iff parts parses the segment of a path (e.g. '.key' or '[0]') as one part that is
+// not a wildcard
+func segmentAddressable(segment string) bool {
+	p, err := parts(segment)
+	if err != nil {
+		return false
+	}
+
+	return slices.Equal(p, []string{segment}) && segment != delimiter+all && segment != indexOpen+all+indexClose
+}
+
+// KindName returns a lowercase name of the yaml.Kind, e.g. 'mapping'
+func KindName(kind yaml.Kind) string {
+	switch kind {
+	case yaml.DocumentNode:
+		return "document"
+	case yaml.SequenceNode:
+		return "sequence"
+	case yaml.MappingNode:
+		return "mapping"
+	case yaml.ScalarNode:
+		return "scalar"
+	case yaml.AliasNode:
+		return "alias"
+	}
+
+	return "unknown"
+}
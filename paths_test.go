@@ -0,0 +1,109 @@
+package yamlfmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPaths(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte("paths:\n  /users/{id}:\n    get:\n      responses:\n        200: {}\ntags: [a]\n"), node))
+
+	// Act
+	paths := Paths(node)
+
+	// Assert
+	assert.Equal(t, []NodePath{
+		{Path: "$", Kind: yaml.MappingNode, Line: 1, Column: 1, Addressable: true},
+		{Path: "$.paths", Kind: yaml.MappingNode, Line: 2, Column: 3, Addressable: true},
+		{Path: "$.paths./users/{id}", Kind: yaml.MappingNode, Line: 3, Column: 5, Addressable: true},
+		{Path: "$.paths./users/{id}.get", Kind: yaml.MappingNode, Line: 4, Column: 7, Addressable: true},
+		{Path: "$.paths./users/{id}.get.responses", Kind: yaml.MappingNode, Line: 5, Column: 9, Addressable: true},
+		{Path: "$.paths./users/{id}.get.responses.200", Kind: yaml.MappingNode, Line: 5, Column: 14, Addressable: true},
+		{Path: "$.tags", Kind: yaml.SequenceNode, Line: 6, Column: 7, Addressable: true},
+		{Path: "$.tags[0]", Kind: yaml.ScalarNode, Line: 6, Column: 8, Addressable: true},
+	}, paths)
+}
+
+func TestPaths_Addressable(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Document string
+		Path     string
+		Expected bool
+	}{
+		"plain key": {
+			Document: "key: {a: 1}",
+			Path:     "$.key.a",
+			Expected: true,
+		},
+		"key with delimiter": {
+			Document: "key.name: {a: 1}",
+			Path:     "$.key.name",
+			Expected: false,
+		},
+		"child of key with delimiter": {
+			Document: "key.name: {a: 1}",
+			Path:     "$.key.name.a",
+			Expected: false,
+		},
+		"key with index": {
+			Document: "key[0]: 1",
+			Path:     "$.key[0]",
+			Expected: false,
+		},
+		"key with closing index": {
+			Document: "key]: 1",
+			Path:     "$.key]",
+			Expected: false,
+		},
+		"wildcard key": {
+			Document: "'*': 1",
+			Path:     "$.*",
+			Expected: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			node := new(yaml.Node)
+			require.NoError(t, yaml.Unmarshal([]byte(test.Document), node))
+
+			// Act
+			paths := Paths(node)
+
+			// Assert
+			var found bool
+			for _, path := range paths {
+				if path.Path == test.Path {
+					found = true
+					assert.Equal(t, test.Expected, path.Addressable)
+				}
+			}
+			assert.True(t, found, "path %q", test.Path)
+		})
+	}
+}
+
+func TestPaths_EmptyDocument(t *testing.T) {
+	t.Parallel()
+	// Act
+	paths := Paths(&yaml.Node{Kind: yaml.DocumentNode})
+
+	// Assert
+	assert.Empty(t, paths)
+}
+
+func TestKindName(t *testing.T) {
+	t.Parallel()
+	// Assert
+	assert.Equal(t, "mapping", KindName(yaml.MappingNode))
+	assert.Equal(t, "scalar", KindName(yaml.ScalarNode))
+	assert.Equal(t, "unknown", KindName(0))
+}
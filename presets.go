@@ -0,0 +1,49 @@
+package yamlfmt
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// names of the presets, see Preset. PresetOpenAPI has the rules of PresetOpenAPI30 but can be detected from the
+// version of a document with DetectPreset
+const (
+	PresetOpenAPI    = "openapi"
+	PresetSwagger2   = "swagger2"
+	PresetOpenAPI30  = "openapi30"
+	PresetOpenAPI31  = "openapi31"
+	PresetKubernetes = "kubernetes"
+	PresetCompose    = "compose"
+	PresetNone       = "none"
+)
+
+// ErrUnknownPreset is returned when a preset does not exist
+var ErrUnknownPreset = errors.New("unknown preset")
+
+// presets by name
+var presets = map[string]func() []Rule{
+	PresetOpenAPI:    DefaultOpenAPIRules,
+	PresetSwagger2:   DefaultSwagger2Rules,
+	PresetOpenAPI30:  DefaultOpenAPIRules,
+	PresetOpenAPI31:  DefaultOpenAPI31Rules,
+	PresetKubernetes: DefaultKubernetesRules,
+	PresetCompose:    DefaultComposeRules,
+	PresetNone:       func() []Rule { return nil },
+}
+
+// Preset returns the rules of the preset with name, PresetNone has no rules
+func Preset(name string) ([]Rule, error) {
+	fn, ok := presets[name]
+	if !ok {
+		return nil, fmt.Errorf("%q: %w", name, ErrUnknownPreset)
+	}
+
+	return fn(), nil
+}
+
+// PresetNames returns the sorted names of the presets
+func PresetNames() []string {
+	return slices.Sorted(maps.Keys(presets))
+}
@@ -0,0 +1,72 @@
+package yamlfmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreset(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Name     string
+		Expected int
+	}{
+		"openapi": {
+			Name:     PresetOpenAPI,
+			Expected: len(DefaultOpenAPIRules()),
+		},
+		"swagger2": {
+			Name:     PresetSwagger2,
+			Expected: len(DefaultSwagger2Rules()),
+		},
+		"openapi 3.1": {
+			Name:     PresetOpenAPI31,
+			Expected: len(DefaultOpenAPI31Rules()),
+		},
+		"kubernetes": {
+			Name:     PresetKubernetes,
+			Expected: len(DefaultKubernetesRules()),
+		},
+		"compose": {
+			Name:     PresetCompose,
+			Expected: len(DefaultComposeRules()),
+		},
+		"none": {
+			Name:     PresetNone,
+			Expected: 0,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			rules, err := Preset(test.Name)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Len(t, rules, test.Expected)
+			require.NoError(t, Validate(rules))
+		})
+	}
+}
+
+func TestPreset_Unknown(t *testing.T) {
+	t.Parallel()
+	// Act
+	rules, err := Preset("helm")
+
+	// Assert
+	require.ErrorIs(t, err, ErrUnknownPreset)
+	assert.Nil(t, rules)
+}
+
+func TestPresetNames(t *testing.T) {
+	t.Parallel()
+	// Act
+	names := PresetNames()
+
+	// Assert
+	assert.Equal(t, []string{"compose", "kubernetes", "none", "openapi", "openapi30", "openapi31", "swagger2"}, names)
+}
@@ -0,0 +1,40 @@
+package yamlfmt
+
+import (
+	"cmp"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Match of Query
+type Match struct {
+	// Path is the canonical path of the node, e.g. '$.paths./users.get'
+	Path string
+	// Line of the node in the source document
+	Line int
+	// Column of the node in the source document
+	Column int
+	// Node that matched
+	Node *yaml.Node
+}
+
+// Query the nodes that match the path with the same syntax as Rule.Path, e.g. '$.paths[*].get' or the relative
+// '.schema'. The matches are in document order
+func Query(node *yaml.Node, path string) ([]Match, error) {
+	rules := []Rule{NewRule(path)}
+	err := Validate(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []Match{}
+	walk(node, rules, func(_ *Rule, _ int, key string, value *yaml.Node) {
+		res = append(res, Match{Path: key, Line: value.Line, Column: value.Column, Node: value})
+	})
+	slices.SortStableFunc(res, func(a, b Match) int {
+		return cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+	})
+
+	return res, nil
+}
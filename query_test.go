@@ -0,0 +1,116 @@
+package yamlfmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// queryDocument is queried by the tests of Query
+const queryDocument = `paths:
+  /users:
+    get:
+      operationId: getUsers
+    post:
+      operationId: createUser
+  /pets:
+    get:
+      operationId: getPets
+tags: [b, a]
+`
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Path     string
+		Expected []string
+	}{
+		"absolute": {
+			Path:     "$.paths./users.get",
+			Expected: []string{"$.paths./users.get"},
+		},
+		"wildcard": {
+			Path:     "$.paths[*].get",
+			Expected: []string{"$.paths./users.get", "$.paths./pets.get"},
+		},
+		"relative": {
+			Path:     ".operationId",
+			Expected: []string{"$.paths./users.get.operationId", "$.paths./users.post.operationId", "$.paths./pets.get.operationId"},
+		},
+		"index": {
+			Path:     "$.tags[1]",
+			Expected: []string{"$.tags[1]"},
+		},
+		"no matches": {
+			Path:     "$.info",
+			Expected: []string{},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			node := new(yaml.Node)
+			require.NoError(t, yaml.Unmarshal([]byte(queryDocument), node))
+
+			// Act
+			matches, err := Query(node, test.Path)
+
+			// Assert
+			require.NoError(t, err)
+			paths := []string{}
+			for _, match := range matches {
+				paths = append(paths, match.Path)
+			}
+			assert.Equal(t, test.Expected, paths)
+		})
+	}
+}
+
+func TestQuery_Match(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte(queryDocument), node))
+
+	// Act
+	matches, err := Query(node, "$.paths./pets.get.operationId")
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, 9, matches[0].Line)
+	assert.Equal(t, 20, matches[0].Column)
+	assert.Equal(t, "getPets", matches[0].Node.Value)
+}
+
+func TestQuery_KeyWithTokens(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte("a]b:\n  type: object\nc:\n  type: string\n"), node))
+
+	// Act
+	matches, err := Query(node, ".type")
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, matches, 2) // the keys are matched by the node, not by parsing the path
+	assert.Equal(t, "$.a]b.type", matches[0].Path)
+	assert.Equal(t, "$.c.type", matches[1].Path)
+}
+
+func TestQuery_InvalidPath(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte(queryDocument), node))
+
+	// Act
+	_, err := Query(node, "$[[")
+
+	// Assert
+	require.EqualError(t, err, "invalid path \"$[[\": char \"[\": illegal token")
+}
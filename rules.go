@@ -3,39 +3,366 @@ package yamlfmt
 // DefaultOpenAPIRules contains an opinionated ordering of an 'openapi.yaml' file based on the tables
 // documented on https://swagger.io/specification/#schema-1
 func DefaultOpenAPIRules() []Rule {
-	operationFn := NewSimpleOrdering("tags", "summary", "description", "externalDocs", "operationId", "parameters", "requestBody", "responses", "callbacks", "deprecated", "security", "servers")
-	mediaTypeFn := NewSimpleOrdering("schema", "example", "examples", "encoding")
-	schemaFn := NewSimpleOrdering("title", "type", "format", "required", "oneOf", "anyOf", "allOf", "properties", "additionalProperties")
+	o := newOpenAPIObjects()
 	return []Rule{
-		NewRule("$", StringOrderingFn, NewSimpleOrdering("openapi", "info", "jsonSchemaDialect", "servers", "paths", "webhooks", "components", "security", "tags", "externalDocs")),
-		NewRule("$.info", StringOrderingFn, NewSimpleOrdering("title", "summary", "description", "termsOfService", "contact", "license", "version")),
-		NewRule("$.info.contact", StringOrderingFn, NewSimpleOrdering("name", "url", "email")),
-		NewRule("$.info.license", StringOrderingFn, NewSimpleOrdering("name", "identifier", "url")),
-		NewRule("$.servers[*]", StringOrderingFn, NewSimpleOrdering("url", "description", "variables")),
-		NewRule("$.servers[*].variables", StringOrderingFn),
-		NewRule("$.servers[*].variables[*]", StringOrderingFn, NewSimpleOrdering("enum", "default", "description")),
-		NewRule("$.components", StringOrderingFn, NewSimpleOrdering("schemas", "responses", "parameters", "examples", "requestBodies", "headers", "securitySchemes", "links", "callbacks", "pathItems")),
-		NewRule("$.paths", StringOrderingFn),
-		NewRule("$.paths[*]", StringOrderingFn, NewSimpleOrdering("$ref", "summary", "description", "get", "put", "post", "delete", "options", "head", "patch", "trace", "servers", "parameters")),
-		NewRule("$.paths[*].get", StringOrderingFn, operationFn),
-		NewRule("$.paths[*].put", StringOrderingFn, operationFn),
-		NewRule("$.paths[*].post", StringOrderingFn, operationFn),
-		NewRule("$.paths[*].delete", StringOrderingFn, operationFn),
-		NewRule("$.paths[*].options", StringOrderingFn, operationFn),
-		NewRule("$.paths[*].head", StringOrderingFn, operationFn),
-		NewRule("$.paths[*].patch", StringOrderingFn, operationFn),
-		NewRule("$.paths[*].trace", StringOrderingFn, operationFn),
-		NewRule("$.paths[*][*].externalDocs", StringOrderingFn, NewSimpleOrdering("description", "url")),
-		NewRule("$.paths[*][*].parameters[*]", StringOrderingFn, NewSimpleOrdering("$ref", "name", "in", "description", "required", "deprecated", "allowEmptyValue", "style", "explode", "allowReserved", "schema")),
-		NewRule("$.paths[*][*].requestBody", StringOrderingFn, NewSimpleOrdering("description", "content", "required")),
-		NewRule("$.paths[*][*].requestBody.content", StringOrderingFn),
-		NewRule("$.paths[*][*].requestBody.content[*]", StringOrderingFn, mediaTypeFn),
-		NewRule("$.paths[*][*].responses", StringOrderingFn),
-		NewRule("$.paths[*][*].responses[*]", StringOrderingFn, NewSimpleOrdering("description", "headers", "content", "links")),
-		NewRule("$.paths[*][*].responses[*].content", StringOrderingFn, mediaTypeFn),
-		NewRule(".schema", StringOrderingFn, schemaFn),
-		NewRule("$.components.schemas[*]", StringOrderingFn, schemaFn),
-		NewRule(".schema.properties", StringOrderingFn),
-		NewRule(".schemas[*].properties", StringOrderingFn),
+		newObjectRule("$", NewSimpleOrdering("openapi", "info", "jsonSchemaDialect", "servers", "paths", "webhooks", "components", "security", "tags", "externalDocs")),
+		newObjectRule("$.info", NewSimpleOrdering("title", "summary", "description", "termsOfService", "contact", "license", "version")),
+		newObjectRule("$.info.contact", NewSimpleOrdering("name", "url", "email")),
+		newObjectRule("$.info.license", NewSimpleOrdering("name", "identifier", "url")),
+		serverRule("$.servers[*]"),
+		newObjectRule("$.paths", PathOrderingFn),
+		o.pathItemRule("$.paths[*]", true),
+		o.componentsRule("$.components"),
+		newObjectRule("$.tags[*]", NewSimpleOrdering("name", "description", "externalDocs")),
+		newObjectRule("$.tags[*].externalDocs", o.externalDocs),
+		newObjectRule("$.externalDocs", o.externalDocs),
+		schemaRule(".schema", o.schema, o.externalDocs),
+		schemaRule("$.components.schemas[*]", o.schema, o.externalDocs),
+	}
+}
+
+// schemaRule for the schemas at path, see Rule.Schema. The 'properties' (sorted alphabetically), the objects of a
+// schema (the 'discriminator', 'xml' and 'externalDocs') and the opaque zones of the user data (the 'const',
+// 'default', 'example' and 'examples') are nested rules, so they match the keywords of every schema but not a property
+// or an extension with the same name
+func schemaRule(path string, schema OrderFn, externalDocs OrderFn) Rule {
+	rule := newObjectRule(path, schema)
+	rule.Schema = true
+	rule.Rules = []Rule{
+		NewRule(".properties", StringOrderingFn),
+		newObjectRule(".discriminator", NewSimpleOrdering("propertyName", "mapping")),
+		newObjectRule(".xml", NewSimpleOrdering("name", "namespace", "prefix", "attribute", "wrapped")),
+		newObjectRule(".externalDocs", externalDocs),
+		NewOpaqueRule(".const"),
+		NewOpaqueRule(".default"),
+		NewOpaqueRule(".example"),
+		NewOpaqueRule(".examples"),
+	}
+
+	return rule
+}
+
+// openAPIMethods are the keys of the operations of a Path Item Object
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// schemaKeywords is the canonical order of the keywords of a Schema Object, the JSON Schema 2020-12 vocabularies with
+// the OpenAPI extensions (and the OpenAPI 3.0 keywords), grouped by purpose
+var schemaKeywords = []string{
+	// identity: which schema this is, where it is defined and what it describes
+	"$schema", "$id", "$anchor", "$dynamicAnchor", "$ref", "$dynamicRef", "$vocabulary", "$comment",
+	"title", "description",
+	// type: the kind of value
+	"type", "format", "nullable", "const", "enum",
+	// validation of numbers
+	"multipleOf", "minimum", "exclusiveMinimum", "maximum", "exclusiveMaximum",
+	// validation of strings
+	"minLength", "maxLength", "pattern", "contentEncoding", "contentMediaType", "contentSchema",
+	// validation of arrays
+	"prefixItems", "items", "additionalItems", "unevaluatedItems", "contains", "minContains", "maxContains",
+	"minItems", "maxItems", "uniqueItems",
+	// validation of objects
+	"required", "properties", "patternProperties", "additionalProperties", "unevaluatedProperties", "propertyNames",
+	"minProperties", "maxProperties", "dependentRequired", "dependentSchemas",
+	// composition of schemas
+	"oneOf", "anyOf", "allOf", "not", "if", "then", "else", "discriminator",
+	// annotations: documentation of the value
+	"default", "examples", "example", "deprecated", "readOnly", "writeOnly", "xml", "externalDocs",
+	// definitions of the subschemas, last as these are usually long
+	"$defs", "definitions",
+}
+
+// openAPIObjects are the orderings of the objects of the OpenAPI 3 specification, every object is ordered the same
+// whether it is inline or in the components (i.e. a Reference Object can be used instead)
+type openAPIObjects struct {
+	pathItem       OrderFn
+	operation      OrderFn
+	externalDocs   OrderFn
+	parameter      OrderFn
+	requestBody    OrderFn
+	mediaType      OrderFn
+	encoding       OrderFn
+	response       OrderFn
+	header         OrderFn
+	example        OrderFn
+	link           OrderFn
+	securityScheme OrderFn
+	oauthFlow      OrderFn
+	schema         OrderFn
+}
+
+// newOpenAPIObjects creates the orderings of the objects based on the order of the fields in the specification
+func newOpenAPIObjects() openAPIObjects {
+	return openAPIObjects{
+		pathItem:       NewSimpleOrdering(append(append([]string{"$ref", "summary", "description"}, openAPIMethods...), "servers", "parameters")...),
+		operation:      NewSimpleOrdering("tags", "summary", "description", "externalDocs", "operationId", "parameters", "requestBody", "responses", "callbacks", "deprecated", "security", "servers"),
+		externalDocs:   NewSimpleOrdering("description", "url"),
+		parameter:      NewSimpleOrdering("$ref", "name", "in", "description", "required", "deprecated", "allowEmptyValue", "style", "explode", "allowReserved", "schema", "example", "examples", "content"),
+		requestBody:    NewSimpleOrdering("$ref", "description", "content", "required"),
+		mediaType:      NewSimpleOrdering("schema", "example", "examples", "encoding"),
+		encoding:       NewSimpleOrdering("contentType", "headers", "style", "explode", "allowReserved"),
+		response:       NewSimpleOrdering("$ref", "description", "headers", "content", "links"),
+		header:         NewSimpleOrdering("$ref", "description", "required", "deprecated", "allowEmptyValue", "style", "explode", "allowReserved", "schema", "example", "examples", "content"),
+		example:        NewSimpleOrdering("$ref", "summary", "description", "value", "externalValue"),
+		link:           NewSimpleOrdering("$ref", "operationRef", "operationId", "parameters", "requestBody", "description", "server"),
+		securityScheme: NewSimpleOrdering("$ref", "type", "description", "name", "in", "scheme", "bearerFormat", "flows", "openIdConnectUrl"),
+		oauthFlow:      NewSimpleOrdering("authorizationUrl", "tokenUrl", "refreshUrl", "scopes"),
+		schema:         NewSimpleOrdering(schemaKeywords...),
+	}
+}
+
+// pathItemRule for the Path Item Object at path and its operations, the callbacks of the operations are ordered if
+// callbacks is true (callbacks of callbacks are not, to keep the rules finite)
+func (o openAPIObjects) pathItemRule(path string, callbacks bool) Rule {
+	rule := newObjectRule(path, o.pathItem)
+	for _, method := range openAPIMethods {
+		rule.Rules = append(rule.Rules, o.operationRule(delimiter+method, callbacks))
+	}
+	rule.Rules = append(rule.Rules, serverRule(".servers[*]"), o.parameterRule(".parameters[*]"))
+
+	return rule
+}
+
+// operationRule for the Operation Object at path, see pathItemRule for callbacks
+func (o openAPIObjects) operationRule(path string, callbacks bool) Rule {
+	responses := newObjectRule(".responses", ResponseOrderingFn)
+	responses.Rules = []Rule{o.responseRule("[*]")}
+
+	rule := newObjectRule(path, o.operation)
+	rule.Rules = []Rule{
+		newObjectRule(".externalDocs", o.externalDocs),
+		o.parameterRule(".parameters[*]"),
+		o.requestBodyRule(".requestBody"),
+		responses,
+		serverRule(".servers[*]"),
+	}
+	if callbacks {
+		rule.Rules = append(rule.Rules, o.callbacksRule(".callbacks"))
+	}
+
+	return rule
+}
+
+// callbacksRule for the map of names to Callback Objects at path, every expression of a Callback Object is a Path
+// Item Object
+func (o openAPIObjects) callbacksRule(path string) Rule {
+	callback := newObjectRule("[*]")
+	callback.Rules = []Rule{o.pathItemRule("[*]", false)}
+
+	rule := NewRule(path, StringOrderingFn)
+	rule.Rules = []Rule{callback}
+
+	return rule
+}
+
+// componentsRule for the Components Object at path, the schemas are ordered by the schema rules
+func (o openAPIObjects) componentsRule(path string) Rule {
+	rule := newObjectRule(path, NewSimpleOrdering("schemas", "responses", "parameters", "examples", "requestBodies", "headers", "securitySchemes", "links", "callbacks", "pathItems"))
+	rule.Rules = []Rule{
+		NewRule(".schemas", StringOrderingFn),
+		NewRule(".responses", StringOrderingFn),
+		o.responseRule(".responses[*]"),
+		NewRule(".parameters", StringOrderingFn),
+		o.parameterRule(".parameters[*]"),
+		o.examplesRule(".examples"),
+		NewRule(".requestBodies", StringOrderingFn),
+		o.requestBodyRule(".requestBodies[*]"),
+		o.headersRule(".headers", true),
+		NewRule(".securitySchemes", StringOrderingFn),
+		o.securitySchemeRule(".securitySchemes[*]"),
+		o.linksRule(".links"),
+		o.callbacksRule(".callbacks"),
+		NewRule(".pathItems", StringOrderingFn),
+		o.pathItemRule(".pathItems[*]", true),
+	}
+
+	return rule
+}
+
+// parameterRule for the Parameter Object at path, its example is an opaque zone
+func (o openAPIObjects) parameterRule(path string) Rule {
+	rule := newObjectRule(path, o.parameter)
+	rule.Rules = []Rule{NewOpaqueRule(".example"), o.examplesRule(".examples"), o.contentRule(".content")}
+
+	return rule
+}
+
+// requestBodyRule for the Request Body Object at path
+func (o openAPIObjects) requestBodyRule(path string) Rule {
+	rule := newObjectRule(path, o.requestBody)
+	rule.Rules = []Rule{o.contentRule(".content")}
+
+	return rule
+}
+
+// contentRule for the map of media types to Media Type Objects at path, the example of a Media Type Object is an
+// opaque zone
+func (o openAPIObjects) contentRule(path string) Rule {
+	encoding := newObjectRule(".encoding[*]", o.encoding)
+	encoding.Rules = []Rule{o.headersRule(".headers", false)}
+
+	mediaType := newObjectRule("[*]", o.mediaType)
+	mediaType.Rules = []Rule{
+		NewOpaqueRule(".example"),
+		o.examplesRule(".examples"),
+		NewRule(".encoding", StringOrderingFn),
+		encoding,
+	}
+
+	rule := NewRule(path, StringOrderingFn)
+	rule.Rules = []Rule{mediaType}
+
+	return rule
+}
+
+// responseRule for the Response Object at path
+func (o openAPIObjects) responseRule(path string) Rule {
+	rule := newObjectRule(path, o.response)
+	rule.Rules = []Rule{o.headersRule(".headers", true), o.contentRule(".content"), o.linksRule(".links")}
+
+	return rule
+}
+
+// headersRule for the map of names to Header Objects at path, the example of a Header Object is an opaque zone. The
+// content of the headers is ordered if content is true, as the content can have an encoding with headers again
+func (o openAPIObjects) headersRule(path string, content bool) Rule {
+	header := newObjectRule("[*]", o.header)
+	header.Rules = []Rule{NewOpaqueRule(".example"), o.examplesRule(".examples")}
+	if content {
+		header.Rules = append(header.Rules, o.contentRule(".content"))
+	}
+
+	rule := NewRule(path, StringOrderingFn)
+	rule.Rules = []Rule{header}
+
+	return rule
+}
+
+// examplesRule for the map of names to Example Objects at path, the values of the examples are opaque zones
+func (o openAPIObjects) examplesRule(path string) Rule {
+	example := newObjectRule("[*]", o.example)
+	example.Rules = []Rule{NewOpaqueRule(".value")}
+
+	rule := NewRule(path, StringOrderingFn)
+	rule.Rules = []Rule{example}
+
+	return rule
+}
+
+// linksRule for the map of names to Link Objects at path, the literal 'requestBody' of a link is an opaque zone
+func (o openAPIObjects) linksRule(path string) Rule {
+	link := newObjectRule("[*]", o.link)
+	link.Rules = []Rule{NewOpaqueRule(".requestBody"), serverRule(".server")}
+
+	rule := NewRule(path, StringOrderingFn)
+	rule.Rules = []Rule{link}
+
+	return rule
+}
+
+// serverRule for the Server Object at path
+func serverRule(path string) Rule {
+	rule := newObjectRule(path, NewSimpleOrdering("url", "description", "variables"))
+	rule.Rules = []Rule{
+		NewRule(".variables", StringOrderingFn),
+		newObjectRule(".variables[*]", NewSimpleOrdering("enum", "default", "description")),
+	}
+
+	return rule
+}
+
+// securitySchemeRule for the Security Scheme Object at path
+func (o openAPIObjects) securitySchemeRule(path string) Rule {
+	rule := newObjectRule(path, o.securityScheme)
+	rule.Rules = []Rule{
+		newObjectRule(".flows", NewSimpleOrdering("implicit", "password", "clientCredentials", "authorizationCode")),
+		newObjectRule(".flows[*]", o.oauthFlow),
+		NewRule(".flows[*].scopes", StringOrderingFn),
+	}
+
+	return rule
+}
+
+// DefaultOpenAPI31Rules extends DefaultOpenAPIRules with the objects that were introduced in OpenAPI 3.1
+func DefaultOpenAPI31Rules() []Rule {
+	return append(DefaultOpenAPIRules(), NewRule("$.webhooks", StringOrderingFn), newOpenAPIObjects().pathItemRule("$.webhooks[*]", true))
+}
+
+// DefaultSwagger2Rules contains an opinionated ordering of a Swagger 2.0 'swagger.yaml' file based on the tables
+// documented on https://swagger.io/specification/v2/
+func DefaultSwagger2Rules() []Rule {
+	operationFn := NewSimpleOrdering("tags", "summary", "description", "externalDocs", "operationId", "consumes", "produces", "parameters", "responses", "schemes", "deprecated", "security")
+	parameterFn := NewSimpleOrdering("$ref", "name", "in", "description", "required", "schema", "type", "format", "allowEmptyValue", "items", "collectionFormat", "default", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum", "maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems", "enum", "multipleOf")
+	responseFn := NewSimpleOrdering("$ref", "description", "schema", "headers", "examples")
+	headerFn := NewSimpleOrdering("description", "type", "format", "items", "collectionFormat", "default", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum", "maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems", "enum", "multipleOf")
+	externalDocsFn := NewSimpleOrdering("description", "url")
+	schemaFn := NewSimpleOrdering("$ref", "title", "description", "type", "format", "required", "allOf", "properties", "additionalProperties", "discriminator", "readOnly", "xml", "externalDocs", "example")
+
+	// opaque zones of the user data outside the schemas, see schemaRule
+	return []Rule{
+		NewOpaqueRule(".parameters[*].default"),
+		NewOpaqueRule(".headers[*].default"),
+		NewOpaqueRule("$.paths[*][*].responses[*].examples"),
+		NewOpaqueRule("$.responses[*].examples"),
+		newObjectRule("$", NewSimpleOrdering("swagger", "info", "host", "basePath", "schemes", "consumes", "produces", "paths", "definitions", "parameters", "responses", "securityDefinitions", "security", "tags", "externalDocs")),
+		newObjectRule("$.info", NewSimpleOrdering("title", "description", "termsOfService", "contact", "license", "version")),
+		newObjectRule("$.info.contact", NewSimpleOrdering("name", "url", "email")),
+		newObjectRule("$.info.license", NewSimpleOrdering("name", "url")),
+		newObjectRule("$.paths", PathOrderingFn),
+		newObjectRule("$.paths[*]", NewSimpleOrdering("$ref", "get", "put", "post", "delete", "options", "head", "patch", "parameters")),
+		newObjectRule("$.paths[*].get", operationFn),
+		newObjectRule("$.paths[*].put", operationFn),
+		newObjectRule("$.paths[*].post", operationFn),
+		newObjectRule("$.paths[*].delete", operationFn),
+		newObjectRule("$.paths[*].options", operationFn),
+		newObjectRule("$.paths[*].head", operationFn),
+		newObjectRule("$.paths[*].patch", operationFn),
+		newObjectRule("$.paths[*][*].externalDocs", externalDocsFn),
+		newObjectRule("$.paths[*].parameters[*]", parameterFn),
+		newObjectRule("$.paths[*][*].parameters[*]", parameterFn),
+		newObjectRule("$.paths[*][*].responses", ResponseOrderingFn),
+		newObjectRule("$.paths[*][*].responses[*]", responseFn),
+		newObjectRule(".headers[*]", headerFn),
+		newObjectRule(".parameters[*].items", headerFn),
+		NewRule("$.parameters", StringOrderingFn),
+		newObjectRule("$.parameters[*]", parameterFn),
+		NewRule("$.responses", StringOrderingFn),
+		newObjectRule("$.responses[*]", responseFn),
+		NewRule("$.securityDefinitions", StringOrderingFn),
+		newObjectRule("$.securityDefinitions[*]", NewSimpleOrdering("type", "description", "name", "in", "flow", "authorizationUrl", "tokenUrl", "scopes")),
+		newObjectRule("$.tags[*]", NewSimpleOrdering("name", "description", "externalDocs")),
+		newObjectRule("$.tags[*].externalDocs", externalDocsFn),
+		newObjectRule("$.externalDocs", externalDocsFn),
+		NewRule("$.definitions", StringOrderingFn),
+		schemaRule(".schema", schemaFn, externalDocsFn),
+		schemaRule("$.definitions[*]", schemaFn, externalDocsFn),
+	}
+}
+
+// DefaultKubernetesRules contains an opinionated ordering of Kubernetes manifests based on the conventional order
+// of the fields of an object: https://kubernetes.io/docs/concepts/overview/working-with-objects/
+func DefaultKubernetesRules() []Rule {
+	containerFn := NewSimpleOrdering("name", "image", "imagePullPolicy", "command", "args", "workingDir", "ports", "env", "envFrom", "resources", "volumeMounts", "livenessProbe", "readinessProbe", "startupProbe", "securityContext")
+	return []Rule{
+		NewRule("$", NewSimpleOrdering("apiVersion", "kind", "metadata", "spec", "data", "stringData", "status")),
+		NewRule("$.metadata", NewSimpleOrdering("name", "generateName", "namespace", "labels", "annotations")),
+		NewRule("$.metadata.labels", StringOrderingFn),
+		NewRule("$.metadata.annotations", StringOrderingFn),
+		NewRule(".containers[*]", containerFn),
+		NewRule(".initContainers[*]", containerFn),
+		NewRule(".env[*]", NewSimpleOrdering("name", "value", "valueFrom")),
+	}
+}
+
+// DefaultComposeRules contains an opinionated ordering of a 'compose.yaml' file based on the Compose specification
+// https://docs.docker.com/reference/compose-file/
+func DefaultComposeRules() []Rule {
+	return []Rule{
+		NewRule("$", NewSimpleOrdering("version", "name", "include", "services", "networks", "volumes", "configs", "secrets")),
+		NewRule("$.services", StringOrderingFn),
+		NewRule("$.services[*]", NewSimpleOrdering("image", "build", "container_name", "command", "entrypoint", "environment", "env_file", "ports", "volumes", "networks", "depends_on", "restart")),
+		NewRule("$.networks", StringOrderingFn),
+		NewRule("$.volumes", StringOrderingFn),
+		NewRule("$.configs", StringOrderingFn),
+		NewRule("$.secrets", StringOrderingFn),
 	}
 }
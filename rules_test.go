@@ -22,3 +22,179 @@ func TestDefaultOpenAPIRules(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, string(expected), string(b))
 }
+
+func TestDefaultOpenAPI31Rules_Components(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	actual, err := os.ReadFile("testdata/components/openapi.yaml")
+	require.NoError(t, err)
+
+	// Act
+	b, err := LintBytes(actual, DefaultOpenAPI31Rules())
+
+	// Assert
+	require.NoError(t, err)
+	expected, err := os.ReadFile("testdata/components/openapi.fmt.yaml")
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(b))
+}
+
+func TestDefaultOpenAPI31Rules_Callbacks(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	actual, err := os.ReadFile("testdata/callbacks/openapi.yaml")
+	require.NoError(t, err)
+
+	// Act
+	b, err := LintBytes(actual, DefaultOpenAPI31Rules())
+
+	// Assert
+	require.NoError(t, err)
+	expected, err := os.ReadFile("testdata/callbacks/openapi.fmt.yaml")
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(b))
+}
+
+func TestDefaultOpenAPI31Rules_Schemas(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	actual, err := os.ReadFile("testdata/schemas/openapi.yaml")
+	require.NoError(t, err)
+
+	// Act
+	b, err := LintBytes(actual, DefaultOpenAPI31Rules())
+
+	// Assert
+	require.NoError(t, err)
+	expected, err := os.ReadFile("testdata/schemas/openapi.fmt.yaml")
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(b))
+}
+
+func TestDefaultOpenAPI31Rules_Zones(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Input    string
+		Expected string
+	}{
+		"schema examples": {
+			Input:    "openapi: 3.1.0\ncomponents:\n  schemas:\n    Pet:\n      type: object\n      examples:\n        - schema:\n            zeta: 1\n            alpha: 2\n            type: 3\n",
+			Expected: "openapi: 3.1.0\ncomponents:\n  schemas:\n    Pet:\n      type: object\n      examples:\n        - schema:\n            zeta: 1\n            alpha: 2\n            type: 3\n",
+		},
+		"property named example": {
+			Input:    "openapi: 3.1.0\ncomponents:\n  schemas:\n    Pet:\n      properties:\n        example:\n          properties:\n            zeta: {type: string}\n            alpha: {type: string}\n          type: object\n      type: object\n",
+			Expected: "openapi: 3.1.0\ncomponents:\n  schemas:\n    Pet:\n      type: object\n      properties:\n        example:\n          type: object\n          properties:\n            alpha: {type: string}\n            zeta: {type: string}\n",
+		},
+		"media type example": {
+			Input:    "openapi: 3.1.0\npaths:\n  /pets:\n    get:\n      responses:\n        \"200\":\n          content:\n            application/json:\n              example: {zeta: 1, alpha: 2}\n              schema: {type: object}\n          description: OK\n",
+			Expected: "openapi: 3.1.0\npaths:\n  /pets:\n    get:\n      responses:\n        \"200\":\n          description: OK\n          content:\n            application/json:\n              schema: {type: object}\n              example: {zeta: 1, alpha: 2}\n",
+		},
+		"media type with a dot": {
+			Input:    "openapi: 3.1.0\npaths:\n  /pets:\n    get:\n      responses:\n        \"200\":\n          content:\n            application/vnd.api+json:\n              example: {properties: {zeta: 1, alpha: 2}}\n          description: OK\n",
+			Expected: "openapi: 3.1.0\npaths:\n  /pets:\n    get:\n      responses:\n        \"200\":\n          description: OK\n          content:\n            application/vnd.api+json:\n              example: {properties: {zeta: 1, alpha: 2}}\n",
+		},
+		"extension with properties": {
+			Input:    "openapi: 3.1.0\nx-config:\n  properties:\n    zeta: 1\n    alpha: 2\n",
+			Expected: "openapi: 3.1.0\nx-config:\n  properties:\n    zeta: 1\n    alpha: 2\n",
+		},
+		"property named default of the items": {
+			Input:    "openapi: 3.1.0\ncomponents:\n  schemas:\n    Pets:\n      items:\n        properties:\n          default:\n            properties:\n              zeta: {type: string}\n              alpha: {type: string}\n      type: array\n",
+			Expected: "openapi: 3.1.0\ncomponents:\n  schemas:\n    Pets:\n      type: array\n      items:\n        properties:\n          default:\n            properties:\n              alpha: {type: string}\n              zeta: {type: string}\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			b, err := LintBytes([]byte(test.Input), DefaultOpenAPI31Rules())
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, test.Expected, string(b))
+		})
+	}
+}
+
+func TestDefaultOpenAPI31Rules_Extensions(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Input    string
+		Expected string
+	}{
+		"tags": {
+			Input:    "openapi: 3.1.0\ntags:\n  - x-order: 1\n    description: Pets\n    name: pet\n    externalDocs: {x-b: 1, url: u}\n",
+			Expected: "openapi: 3.1.0\ntags:\n  - name: pet\n    description: Pets\n    externalDocs: {url: u, x-b: 1}\n    x-order: 1\n",
+		},
+		"external docs": {
+			Input:    "openapi: 3.1.0\nexternalDocs:\n  x-b: 1\n  url: u\n  description: d\n",
+			Expected: "openapi: 3.1.0\nexternalDocs:\n  description: d\n  url: u\n  x-b: 1\n",
+		},
+		"schema xml and discriminator": {
+			Input:    "openapi: 3.1.0\ncomponents:\n  schemas:\n    Pet:\n      xml: {x-b: 1, name: pet}\n      discriminator: {x-b: 1, mapping: {}, propertyName: type}\n",
+			Expected: "openapi: 3.1.0\ncomponents:\n  schemas:\n    Pet:\n      discriminator: {propertyName: type, mapping: {}, x-b: 1}\n      xml: {name: pet, x-b: 1}\n",
+		},
+		"operation servers": {
+			Input:    "openapi: 3.1.0\npaths:\n  /pets:\n    get:\n      servers:\n        - x-b: 1\n          url: u\n",
+			Expected: "openapi: 3.1.0\npaths:\n  /pets:\n    get:\n      servers:\n        - url: u\n          x-b: 1\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			b, err := LintBytes([]byte(test.Input), DefaultOpenAPI31Rules())
+
+			// Assert
+			require.NoError(t, err)
+			require.Equal(t, test.Expected, string(b))
+		})
+	}
+}
+
+func TestDefaultKubernetesRules(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	actual, err := os.ReadFile("testdata/kubernetes/deployment.yaml")
+	require.NoError(t, err)
+
+	// Act
+	b, err := LintBytes(actual, DefaultKubernetesRules())
+
+	// Assert
+	require.NoError(t, err)
+	expected, err := os.ReadFile("testdata/kubernetes/deployment.fmt.yaml")
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(b))
+}
+
+func TestDefaultComposeRules(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	actual, err := os.ReadFile("testdata/compose/compose.yaml")
+	require.NoError(t, err)
+
+	// Act
+	b, err := LintBytes(actual, DefaultComposeRules())
+
+	// Assert
+	require.NoError(t, err)
+	expected, err := os.ReadFile("testdata/compose/compose.fmt.yaml")
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(b))
+}
+
+func TestDefaultSwagger2Rules(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	actual, err := os.ReadFile("testdata/swagger2/swagger.yaml")
+	require.NoError(t, err)
+
+	// Act
+	b, err := LintBytes(actual, DefaultSwagger2Rules())
+
+	// Assert
+	require.NoError(t, err)
+	expected, err := os.ReadFile("testdata/swagger2/swagger.fmt.yaml")
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(b))
+}
@@ -0,0 +1,259 @@
+package yamlfmt
+
+import (
+	"bytes"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+	"weak"
+
+	"gopkg.in/yaml.v3"
+)
+
+// strTag is the resolved tag of a yaml string scalar
+const strTag = "!!str"
+
+// Chomping selects which strings are converted by NewBlockScalarStyling based on their trailing line breaks. The
+// chomping indicator of a block scalar is always derived from the string itself ('-' without, no indicator with one
+// and '+' with multiple trailing line breaks) as any other indicator would change the decoded value,
+// see https://yaml.org/spec/1.2.2/#8112-block-chomping-indicator
+type Chomping int
+
+const (
+	// ChompAny converts strings regardless of their trailing line breaks
+	ChompAny Chomping = iota
+	// ChompStrip only converts strings without a trailing line break, e.g. '|-'
+	ChompStrip
+	// ChompClip only converts strings with exactly one trailing line break, e.g. '|'
+	ChompClip
+	// ChompKeep only converts strings with more than one trailing line break, e.g. '|+'
+	ChompKeep
+)
+
+// allows returns true iff the trailing line breaks of value are represented by the Chomping indicator
+func (c Chomping) allows(value string) bool {
+	trailing := len(value) - len(strings.TrimRight(value, "\n"))
+	switch c {
+	case ChompStrip:
+		return trailing == 0
+	case ChompClip:
+		return trailing == 1
+	case ChompKeep:
+		return trailing > 1
+	case ChompAny:
+	}
+
+	return true
+}
+
+// NewBlockScalarStyling converts a yaml.ScalarNode string that spans multiple lines or that is longer than width
+// characters into a block scalar with the provided style (yaml.LiteralStyle '|' or yaml.FoldedStyle '>'). A width of
+// zero or less only converts multi-line strings. The lines of a folded scalar (also one that was folded already) are
+// wrapped at single spaces to width when LintBytes encodes the node, the lines of a literal scalar are kept as they are.
+//
+// The conversion is only applied if the string decodes to exactly the same value in the block style, otherwise
+// (e.g. lines with trailing whitespace) the original style is preserved. The same holds for the wrapped lines.
+func NewBlockScalarStyling(style yaml.Style, width int, chomping Chomping) OrderFn {
+	return func(_ string, value *yaml.Node) {
+		if value == nil || value.Kind != yaml.ScalarNode || value.ShortTag() != strTag {
+			return // only restyle string scalars
+		}
+
+		if value.Style != style {
+			if !strings.Contains(value.Value, "\n") && (width <= 0 || utf8.RuneCountInString(value.Value) <= width) {
+				return // a short single line
+			}
+
+			if !chomping.allows(value.Value) || !preserves(style, value.Value) {
+				return
+			}
+
+			value.Style = style
+		}
+
+		if style == yaml.FoldedStyle && width > 0 {
+			setWidth(value, width)
+		}
+	}
+}
+
+// preserves returns true iff encoding value as a block scalar with style decodes to value again
+func preserves(style yaml.Style, value string) bool {
+	b, err := yaml.Marshal(&yaml.Node{Kind: yaml.ScalarNode, Style: style, Value: value})
+	if err != nil || len(b) == 0 || (b[0] != '|' && b[0] != '>') {
+		return false // the encoder fell back to a flow style
+	}
+
+	var decoded string
+	err = yaml.Unmarshal(b, &decoded)
+
+	return err == nil && decoded == value
+}
+
+// widths of the folded scalars of NewBlockScalarStyling by the weak.Pointer of their node, the yaml.v3 encoder does not
+// wrap lines so LintBytes wraps them after encoding (see wrapFolded)
+var widths sync.Map
+
+// setWidth to wrap the lines of the folded scalar node at, until the node is garbage collected
+func setWidth(node *yaml.Node, width int) {
+	key := weak.Make(node)
+	if _, loaded := widths.Swap(key, width); !loaded {
+		runtime.AddCleanup(node, func(key weak.Pointer[yaml.Node]) {
+			widths.Delete(key)
+		}, key)
+	}
+}
+
+// widthOf the folded scalar node, zero if its lines are not wrapped
+func widthOf(node *yaml.Node) int {
+	if width, ok := widths.Load(weak.Make(node)); ok {
+		return width.(int)
+	}
+
+	return 0
+}
+
+// fold is a folded block scalar in the encoded output of LintBytes that is wrapped at width
+type fold struct {
+	// line of the block scalar header (e.g. '>-'), starting at 1
+	line  int
+	width int
+	// value of the scalar, the wrapped lines must decode to the same value
+	value string
+}
+
+// wrapFolded wraps the lines of the folded scalars in b, the encoded documents of LintBytes, at their width (see
+// setWidth). The documents are the nodes that were encoded into b in order
+func wrapFolded(b []byte, documents []*yaml.Node) []byte {
+	if !slices.ContainsFunc(documents, hasWidth) {
+		return b
+	}
+
+	var folds []fold
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+	for _, document := range documents {
+		encoded := new(yaml.Node)
+		if decoder.Decode(encoded) != nil {
+			return b
+		}
+		folds = appendFolds(folds, document, encoded)
+	}
+
+	lines := strings.Split(string(b), "\n")
+	for i := len(folds) - 1; i >= 0; i-- { // from the end, as the wrapped lines move the lines below
+		lines = folds[i].wrap(lines)
+	}
+
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// hasWidth returns true iff the node or one of its children is a folded scalar with a width
+func hasWidth(node *yaml.Node) bool {
+	return node != nil && (widthOf(node) > 0 || slices.ContainsFunc(node.Content, hasWidth))
+}
+
+// appendFolds of the node to the folds in document order, the encoded node is the same node decoded from the encoded
+// output
+func appendFolds(folds []fold, node *yaml.Node, encoded *yaml.Node) []fold {
+	if node.Kind != encoded.Kind || len(node.Content) != len(encoded.Content) {
+		return folds
+	}
+
+	if width := widthOf(node); width > 0 && node.Style == yaml.FoldedStyle && encoded.Style == yaml.FoldedStyle {
+		folds = append(folds, fold{line: encoded.Line, width: width, value: node.Value})
+	}
+	for i := range node.Content {
+		folds = appendFolds(folds, node.Content[i], encoded.Content[i])
+	}
+
+	return folds
+}
+
+// blockHeader matches the end of the line of a block scalar header without an indentation indicator, e.g. 'key: >-'
+var blockHeader = regexp.MustCompile(`>[-+]?( #.*)?$`)
+
+// wrap the lines of the fold at its width, the lines are returned as they are if the wrapped lines do not decode to
+// the value of the fold. Only lines that are folded are wrapped: a line break between two lines that do not start with
+// a space folds into a single space (see https://yaml.org/spec/1.2.2/#813-folded-style), so the lines are broken at a
+// single space between two words
+func (f fold) wrap(lines []string) []string {
+	header := blockHeader.FindString(lines[f.line-1])
+	if header == "" {
+		return lines // an indentation indicator is relative to the parent, the scalar cannot be decoded on its own
+	}
+
+	start, end := f.line, f.line
+	indent := -1
+	for ; end < len(lines); end++ {
+		line := lines[end]
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		lineIndent := len(line) - len(strings.TrimLeft(line, " "))
+		if indent < 0 {
+			indent = lineIndent
+		}
+		if lineIndent < indent {
+			break
+		}
+	}
+	if indent <= 0 {
+		return lines
+	}
+
+	var wrapped []string
+	for _, line := range lines[start:end] {
+		if len(line) > indent && !isBlank(line[indent]) {
+			wrapped = append(wrapped, wrapLine(line, indent, f.width)...)
+			continue
+		}
+		wrapped = append(wrapped, line)
+	}
+
+	// the block scalar on its own, the same as within the document
+	document := strings.SplitN(header, " #", 2)[0] + "\n" + strings.Join(wrapped, "\n")
+	if end < len(lines) {
+		document += "\n"
+	}
+	var decoded string
+	if yaml.Unmarshal([]byte(document), &decoded) != nil || decoded != f.value {
+		return lines
+	}
+
+	return slices.Concat(lines[:start], wrapped, lines[end:])
+}
+
+// wrapLine breaks the line at single spaces after the indent such that every line is at most width characters, unless
+// a word does not fit
+func wrapLine(line string, indent int, width int) []string {
+	var res []string
+	for utf8.RuneCountInString(line) > width {
+		cut := -1
+		for i := indent + 1; i < len(line)-1; i++ {
+			if line[i] != ' ' || isBlank(line[i-1]) || isBlank(line[i+1]) {
+				continue // not a single space between two words
+			}
+			if cut >= 0 && utf8.RuneCountInString(line[:i]) > width {
+				break
+			}
+			cut = i
+		}
+		if cut < 0 {
+			break
+		}
+
+		res = append(res, line[:cut])
+		line = line[:indent] + line[cut+1:]
+	}
+
+	return append(res, line)
+}
+
+// isBlank returns true iff the character is a space or a tab
+func isBlank(c byte) bool {
+	return c == ' ' || c == '\t'
+}
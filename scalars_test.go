@@ -0,0 +1,216 @@
+package yamlfmt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewBlockScalarStyling_Converts(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Style    yaml.Style
+		Width    int
+		Chomping Chomping
+		Value    string
+	}{
+		"multi-line literal": {
+			Style: yaml.LiteralStyle,
+			Value: "first\nsecond",
+		},
+		"multi-line folded": {
+			Style: yaml.FoldedStyle,
+			Value: "first\nsecond\n",
+		},
+		"over-long literal": {
+			Style: yaml.LiteralStyle,
+			Width: 10,
+			Value: "a rather long single line",
+		},
+		"strip chomping": {
+			Style:    yaml.LiteralStyle,
+			Chomping: ChompStrip,
+			Value:    "first\nsecond",
+		},
+		"clip chomping": {
+			Style:    yaml.LiteralStyle,
+			Chomping: ChompClip,
+			Value:    "first\nsecond\n",
+		},
+		"keep chomping": {
+			Style:    yaml.LiteralStyle,
+			Chomping: ChompKeep,
+			Value:    "first\nsecond\n\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			node := &yaml.Node{Kind: yaml.ScalarNode, Tag: strTag, Style: yaml.DoubleQuotedStyle, Value: test.Value}
+
+			// Act
+			NewBlockScalarStyling(test.Style, test.Width, test.Chomping)("", node)
+
+			// Assert
+			assert.Equal(t, test.Style, node.Style)
+			assert.Equal(t, test.Value, node.Value)
+		})
+	}
+}
+
+func TestNewBlockScalarStyling_Preserves(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Style    yaml.Style
+		Width    int
+		Chomping Chomping
+		Node     *yaml.Node
+	}{
+		"short single line": {
+			Style: yaml.LiteralStyle,
+			Width: 80,
+			Node:  &yaml.Node{Kind: yaml.ScalarNode, Tag: strTag, Value: "short"},
+		},
+		"single line without width": {
+			Style: yaml.LiteralStyle,
+			Node:  &yaml.Node{Kind: yaml.ScalarNode, Tag: strTag, Value: "a rather long single line"},
+		},
+		"not a string": {
+			Style: yaml.LiteralStyle,
+			Width: 1,
+			Node:  &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: "123456789"},
+		},
+		"not a scalar": {
+			Style: yaml.LiteralStyle,
+			Node:  &yaml.Node{Kind: yaml.MappingNode},
+		},
+		"trailing whitespace": {
+			Style: yaml.LiteralStyle,
+			Node:  &yaml.Node{Kind: yaml.ScalarNode, Tag: strTag, Style: yaml.DoubleQuotedStyle, Value: "trailing \nspace"},
+		},
+		"folded more-indented line": {
+			Style: yaml.FoldedStyle,
+			Node:  &yaml.Node{Kind: yaml.ScalarNode, Tag: strTag, Style: yaml.DoubleQuotedStyle, Value: "first\n  indented\nlast"},
+		},
+		"chomping mismatch": {
+			Style:    yaml.LiteralStyle,
+			Chomping: ChompKeep,
+			Node:     &yaml.Node{Kind: yaml.ScalarNode, Tag: strTag, Style: yaml.DoubleQuotedStyle, Value: "first\nsecond"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			expected := *test.Node
+
+			// Act
+			NewBlockScalarStyling(test.Style, test.Width, test.Chomping)("", test.Node)
+
+			// Assert
+			assert.Equal(t, expected, *test.Node)
+		})
+	}
+}
+
+func TestNewBlockScalarStyling_NilNode(t *testing.T) {
+	t.Parallel()
+	// Act
+	fn := func() {
+		NewBlockScalarStyling(yaml.LiteralStyle, 0, ChompAny)("", nil)
+	}
+
+	// Assert
+	assert.NotPanics(t, fn)
+}
+
+func TestNewBlockScalarStyling_LintBytes(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	b := []byte("info:\n  description: \"first line\\nsecond line\\n\"\n  title: " + strings.Repeat("x", 20) + "\n")
+	rules := []Rule{NewRule(".description", NewBlockScalarStyling(yaml.LiteralStyle, 10, ChompAny))}
+
+	// Act
+	actual, err := LintBytes(b, rules)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "info:\n  description: |\n    first line\n    second line\n  title: "+strings.Repeat("x", 20)+"\n", string(actual))
+
+	var original, formatted map[string]map[string]string
+	require.NoError(t, yaml.Unmarshal(b, &original))
+	require.NoError(t, yaml.Unmarshal(actual, &formatted))
+	assert.Equal(t, original, formatted)
+}
+
+func TestNewBlockScalarStyling_Wraps(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Style    yaml.Style
+		Input    string
+		Expected string
+	}{
+		"long single line": {
+			Style:    yaml.FoldedStyle,
+			Input:    "description: aaaa bbbb cccc dddd eeee\n",
+			Expected: "description: >-\n  aaaa bbbb cccc\n  dddd eeee\n",
+		},
+		"folded line": {
+			Style:    yaml.FoldedStyle,
+			Input:    "description: >-\n  aaaa bbbb cccc dddd eeee\n",
+			Expected: "description: >-\n  aaaa bbbb cccc\n  dddd eeee\n",
+		},
+		"multiple lines": {
+			Style:    yaml.FoldedStyle,
+			Input:    "a:\n  - description: \"aaaa bbbb cccc\\ndddd eeee ffff gggg\"\n",
+			Expected: "a:\n  - description: >-\n      aaaa bbbb\n      cccc\n\n      dddd eeee\n      ffff gggg\n",
+		},
+		"word longer than the width": {
+			Style:    yaml.FoldedStyle,
+			Input:    "description: aaaabbbbccccddddeeee ffff gggg\n",
+			Expected: "description: >-\n  aaaabbbbccccddddeeee\n  ffff gggg\n",
+		},
+		"multiple spaces": {
+			Style:    yaml.FoldedStyle,
+			Input:    "description: >-\n  aaaa  bbbb  cccc  dddd  eeee\n",
+			Expected: "description: >-\n  aaaa  bbbb  cccc  dddd  eeee\n",
+		},
+		"comment": {
+			Style:    yaml.FoldedStyle,
+			Input:    "description: >- # note\n  aaaa bbbb cccc dddd eeee\n",
+			Expected: "description: >- # note\n  aaaa bbbb cccc\n  dddd eeee\n",
+		},
+		"literal": {
+			Style:    yaml.LiteralStyle,
+			Input:    "description: aaaa bbbb cccc dddd eeee\n",
+			Expected: "description: |-\n  aaaa bbbb cccc dddd eeee\n",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			rules := []Rule{NewRule(".description", NewBlockScalarStyling(test.Style, 16, ChompAny))}
+
+			// Act
+			actual, err := LintBytes([]byte(test.Input), rules)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, string(actual))
+
+			var original, formatted any
+			require.NoError(t, yaml.Unmarshal([]byte(test.Input), &original))
+			require.NoError(t, yaml.Unmarshal(actual, &formatted))
+			assert.Equal(t, original, formatted)
+
+			again, err := LintBytes(actual, rules)
+			require.NoError(t, err)
+			assert.Equal(t, string(actual), string(again))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package yamlfmt
+
+import (
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// subschemaKeywords have a schema (or a sequence of schemas) as value, e.g. 'not' or 'allOf'
+var subschemaKeywords = []string{
+	"items", "prefixItems", "additionalItems", "unevaluatedItems", "contains", "additionalProperties",
+	"unevaluatedProperties", "propertyNames", "allOf", "anyOf", "oneOf", "not", "if", "then", "else", "contentSchema",
+}
+
+// namedSubschemaKeywords have a mapping of names to schemas as value, e.g. 'properties'
+var namedSubschemaKeywords = []string{"properties", "patternProperties", "dependentSchemas", "$defs", "definitions"}
+
+// NewSchemaRule constructor for a Rule that addresses schemas, see Rule.Schema
+func NewSchemaRule(path string, fns ...OrderFn) Rule {
+	return Rule{Path: path, Functions: fns, Schema: true}
+}
+
+// subschemas of the schema at path in document order, following the JSON Schema keywords that contain schemas. Only
+// schemas that are a yaml.MappingNode are returned (e.g. not the boolean 'additionalProperties: false')
+func subschemas(path string, node *yaml.Node) []child {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var candidates []child
+	for _, keyword := range children(path, node) {
+		key := strings.TrimPrefix(keyword.part, delimiter)
+		switch {
+		case slices.Contains(subschemaKeywords, key) && keyword.Node.Kind == yaml.SequenceNode:
+			candidates = append(candidates, children(keyword.Path, keyword.Node)...)
+		case slices.Contains(subschemaKeywords, key):
+			candidates = append(candidates, keyword)
+		case slices.Contains(namedSubschemaKeywords, key):
+			candidates = append(candidates, children(keyword.Path, keyword.Node)...)
+		}
+	}
+
+	var res []child
+	for _, candidate := range candidates {
+		if candidate.Node.Kind == yaml.MappingNode {
+			res = append(res, candidate)
+		}
+	}
+
+	return res
+}
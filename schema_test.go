@@ -0,0 +1,84 @@
+package yamlfmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSubschemas(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte(`properties:
+  name: {type: string}
+  any: true
+items: {type: string}
+allOf:
+  - {type: object}
+  - {required: [name]}
+additionalProperties: false
+not: {type: "null"}
+$defs:
+  id: {type: integer}
+enum: [{type: string}]
+example: {type: string}
+`), node))
+
+	// Act
+	res := subschemas("$", node.Content[0])
+
+	// Assert
+	var paths []string
+	for _, subschema := range res {
+		paths = append(paths, subschema.Path)
+	}
+	assert.Equal(t, []string{"$.properties.name", "$.items", "$.allOf[0]", "$.allOf[1]", "$.not", "$.$defs.id"}, paths)
+}
+
+func TestSubschemas_NotAMapping(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := subschemas("$", &yaml.Node{Kind: yaml.ScalarNode, Value: "true"})
+
+	// Assert
+	assert.Empty(t, res)
+}
+
+func TestLint_SchemaRule(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte(`schema:
+  properties:
+    schema:
+      items:
+        format: date
+        type: string
+      type: array
+  type: object
+`), node))
+	rules := []Rule{NewSchemaRule(".schema", NewSimpleOrdering("type", "format"))}
+	var paths []string
+
+	// Act
+	LintWithTrace(node, rules, func(change Change) {
+		paths = append(paths, change.Path)
+	})
+
+	// Assert
+	assert.Equal(t, []string{"$.schema", "$.schema.properties.schema", "$.schema.properties.schema.items"}, paths)
+	b, err := yaml.Marshal(node)
+	require.NoError(t, err)
+	assert.Equal(t, `schema:
+    type: object
+    properties:
+        schema:
+            type: array
+            items:
+                type: string
+                format: date
+`, string(b))
+}
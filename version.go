@@ -0,0 +1,92 @@
+package yamlfmt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Version of an OpenAPI (or Swagger) document, see DetectVersion
+type Version string
+
+// versions of the specification that have a preset
+const (
+	VersionSwagger20 Version = "2.0"
+	VersionOpenAPI30 Version = "3.0"
+	VersionOpenAPI31 Version = "3.1"
+)
+
+// ErrUnknownVersion is returned when a document has no (supported) 'openapi' or 'swagger' version
+var ErrUnknownVersion = errors.New("unknown openapi version")
+
+// versionPresets are the names of the preset of every Version
+var versionPresets = map[Version]string{
+	VersionSwagger20: PresetSwagger2,
+	VersionOpenAPI30: PresetOpenAPI30,
+	VersionOpenAPI31: PresetOpenAPI31,
+}
+
+// ParseVersion of the specification, e.g. '3.1' or '3.1.0'. Only the major and minor version are significant
+func ParseVersion(s string) (Version, error) {
+	major, rest, _ := strings.Cut(s, ".")
+	minor, _, _ := strings.Cut(rest, ".")
+	version := Version(major + "." + minor)
+	if _, ok := versionPresets[version]; !ok {
+		return "", fmt.Errorf("%q: %w, should be 2.0, 3.0 or 3.1", s, ErrUnknownVersion)
+	}
+
+	return version, nil
+}
+
+// DetectVersion of the document from the 'openapi' (e.g. '3.1.0') or 'swagger' (i.e. '2.0') key of its root
+func DetectVersion(node *yaml.Node) (Version, error) {
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node == nil || node.Kind != yaml.MappingNode {
+		return "", fmt.Errorf("document has no 'openapi' or 'swagger' key: %w", ErrUnknownVersion)
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		switch {
+		case key == "swagger" && value.Value == string(VersionSwagger20):
+			return VersionSwagger20, nil
+		case key == "swagger":
+			return "", fmt.Errorf("swagger %q: %w", value.Value, ErrUnknownVersion)
+		case key == "openapi" && strings.HasPrefix(value.Value, "3."):
+			return ParseVersion(value.Value)
+		case key == "openapi":
+			return "", fmt.Errorf("openapi %q: %w", value.Value, ErrUnknownVersion)
+		}
+	}
+
+	return "", fmt.Errorf("document has no 'openapi' or 'swagger' key: %w", ErrUnknownVersion)
+}
+
+// Preset returns the name of the preset of the Version
+func (v Version) Preset() (string, error) {
+	preset, ok := versionPresets[v]
+	if !ok {
+		return "", fmt.Errorf("%q: %w", string(v), ErrUnknownVersion)
+	}
+
+	return preset, nil
+}
+
+// DetectPreset returns the preset of the Version of the document (see DetectVersion) if the preset is PresetOpenAPI,
+// any other preset is returned as is
+func DetectPreset(preset string, node *yaml.Node) (string, error) {
+	if preset != PresetOpenAPI {
+		return preset, nil
+	}
+
+	version, err := DetectVersion(node)
+	if err != nil {
+		return "", err
+	}
+
+	return version.Preset()
+}
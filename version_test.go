@@ -0,0 +1,151 @@
+package yamlfmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDetectVersion(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Document string
+		Expected Version
+	}{
+		"swagger 2.0": {
+			Document: "swagger: \"2.0\"\ninfo: {}\n",
+			Expected: VersionSwagger20,
+		},
+		"openapi 3.0": {
+			Document: "info: {}\nopenapi: 3.0.3\n",
+			Expected: VersionOpenAPI30,
+		},
+		"openapi 3.1": {
+			Document: "openapi: \"3.1.0\"\n",
+			Expected: VersionOpenAPI31,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			node := new(yaml.Node)
+			require.NoError(t, yaml.Unmarshal([]byte(test.Document), node))
+
+			// Act
+			version, err := DetectVersion(node)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, version)
+		})
+	}
+}
+
+func TestDetectVersion_Errors(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Document string
+		Expected string
+	}{
+		"no version": {
+			Document: "info: {}\n",
+			Expected: "document has no 'openapi' or 'swagger' key: unknown openapi version",
+		},
+		"not a mapping": {
+			Document: "- openapi\n",
+			Expected: "document has no 'openapi' or 'swagger' key: unknown openapi version",
+		},
+		"unknown swagger version": {
+			Document: "swagger: \"1.2\"\n",
+			Expected: "swagger \"1.2\": unknown openapi version",
+		},
+		"unknown openapi version": {
+			Document: "openapi: 4.0.0\n",
+			Expected: "openapi \"4.0.0\": unknown openapi version",
+		},
+		"unknown openapi minor version": {
+			Document: "openapi: 3.2.0\n",
+			Expected: "\"3.2.0\": unknown openapi version, should be 2.0, 3.0 or 3.1",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			node := new(yaml.Node)
+			require.NoError(t, yaml.Unmarshal([]byte(test.Document), node))
+
+			// Act
+			_, err := DetectVersion(node)
+
+			// Assert
+			require.ErrorIs(t, err, ErrUnknownVersion)
+			require.EqualError(t, err, test.Expected)
+		})
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Version  string
+		Expected Version
+		Err      bool
+	}{
+		"major and minor": {
+			Version:  "3.1",
+			Expected: VersionOpenAPI31,
+		},
+		"patch": {
+			Version:  "3.0.3",
+			Expected: VersionOpenAPI30,
+		},
+		"swagger": {
+			Version:  "2.0",
+			Expected: VersionSwagger20,
+		},
+		"major": {
+			Version: "3",
+			Err:     true,
+		},
+		"unknown": {
+			Version: "1.0",
+			Err:     true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			version, err := ParseVersion(test.Version)
+
+			// Assert
+			if test.Err {
+				require.ErrorIs(t, err, ErrUnknownVersion)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, version)
+		})
+	}
+}
+
+func TestDetectPreset(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte("swagger: \"2.0\"\n"), node))
+
+	// Act
+	detected, err := DetectPreset(PresetOpenAPI, node)
+	other, otherErr := DetectPreset(PresetKubernetes, node)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, PresetSwagger2, detected)
+	require.NoError(t, otherErr)
+	assert.Equal(t, PresetKubernetes, other)
+}
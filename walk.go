@@ -0,0 +1,269 @@
+package yamlfmt
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// visitFn is called by walk for every node that matches a Rule, index is the index of the Rule in the rules passed to
+// walk (or of the Rule that declares the nested Rule)
+type visitFn func(rule *Rule, index int, path string, value *yaml.Node)
+
+// walk the yaml.Node breadth first and call visit for every Rule (in order) that matches the path of a node. Every
+// node is reached once for all rules: the rules are matched by following the path of the node through a trie of the
+// Rule.Path's, and the children of a node are reached after the rules ran on it
+func walk(node *yaml.Node, rules []Rule, visit visitFn) {
+	if node == nil || len(rules) == 0 {
+		return
+	}
+
+	cursor := node
+	var path string
+	if node.Kind == yaml.DocumentNode { // document node, the root path starts with $
+		path = root
+
+		if len(cursor.Content) == 0 {
+			return // nothing to order
+		}
+		cursor = cursor.Content[0]
+	}
+
+	m := newMatcher(rules)
+	pending := map[*yaml.Node][]int{}     // subschemas of the schemas a Rule.Schema matched, by Rule index
+	seen := map[int]map[*yaml.Node]bool{} // schemas visited by a Rule.Schema, a subschema can match as well
+	queue := []step{m.start(path, cursor)}
+	for len(queue) > 0 {
+		// dequeue key=value pair
+		s := queue[0]
+		queue = queue[1:]
+
+		// run every rule that matches in order
+		var scopes []*trie // nested rules of the rules that ran on the node
+		for _, i := range m.match(s, pending[s.Node]) {
+			rule := &m.rules[i]
+			if rule.Schema {
+				if seen[i] == nil {
+					seen[i] = map[*yaml.Node]bool{}
+				}
+				if seen[i][s.Node] {
+					continue
+				}
+				seen[i][s.Node] = true
+
+				for _, subschema := range subschemas(s.Path, s.Node) {
+					pending[subschema.Node] = append(pending[subschema.Node], i)
+				}
+			}
+
+			visit(rule, m.top[i], s.Path, s.Node)
+			if scope := m.scope(i); scope != nil {
+				scopes = append(scopes, scope)
+			}
+		}
+		delete(pending, s.Node)
+
+		// add next to queue, after the rules ran so the paths of the next steps are in the order of the rules
+		queue = append(queue, m.next(s, scopes)...)
+	}
+}
+
+// step is a node reached by walk
+type step struct {
+	child
+	// parts of the Path, one for every key (or index) from the start of walk
+	parts []string
+	// states of the trie of the absolute rules that the parts reached
+	states []*trie
+	// scoped are the states of the tries of the nested Rule.Rules that the parts below their matches reached
+	scoped []*trie
+	// opaque is true if the node is in the zone of a Rule.Opaque: no Rule matches the node or its children
+	opaque bool
+}
+
+// matcher matches the rules against the steps of walk
+type matcher struct {
+	// rules with their nested Rule.Rules in pre-order, the Path of a nested Rule is joined to the Path of its parent
+	// (e.g. '.schema.discriminator'). The indexes of walk are the indexes in the rules
+	rules []Rule
+	// top is the index of the Rule in the rules passed to walk that is (or declares) the Rule at the same index
+	top []int
+	// scopes are the tries of the nested Rule.Rules of the Rule at the same index, nil until the Rule matched (see
+	// scope) or if it has none
+	scopes []*trie
+	// absolute rules by their parts
+	absolute *trie
+	// relative rules (e.g. '.schema') are matched against the end of every path
+	relative []int
+	patterns []pattern
+	// zones of the Rule.Opaque rules
+	zones []pattern
+}
+
+// newMatcher parses the Rule.Path of every Rule once, it panics if a path is invalid
+func newMatcher(rules []Rule) matcher {
+	m := matcher{absolute: &trie{}}
+	for i, rule := range rules {
+		p := newPattern(rule.Path)
+		switch {
+		case rule.Opaque:
+			m.zones = append(m.zones, p)
+		case p.relative:
+			m.relative = append(m.relative, m.add(rule, i))
+			m.patterns = append(m.patterns, p)
+		default:
+			m.absolute.add(p.parts, m.add(rule, i))
+		}
+	}
+
+	return m
+}
+
+// add the Rule to the rules of the matcher, it returns the index of the Rule
+func (m *matcher) add(rule Rule, top int) int {
+	m.rules = append(m.rules, rule)
+	m.top = append(m.top, top)
+	m.scopes = append(m.scopes, nil)
+
+	return len(m.rules) - 1
+}
+
+// scope returns the trie of the nested Rule.Rules of the Rule at index, nil if it has none. The nested rules are added
+// when the Rule first matched, as a preset can nest many rules of which a document only uses a few
+func (m *matcher) scope(index int) *trie {
+	rule := m.rules[index]
+	if m.scopes[index] != nil || len(rule.Rules) == 0 {
+		return m.scopes[index]
+	}
+
+	scope := &trie{}
+	for _, nested := range rule.Rules {
+		p := mustParts(nested.Path)
+		if nested.Opaque {
+			scope.insert(p).zone = true
+			continue
+		}
+
+		nested.Path = rule.Path + nested.Path
+		scope.add(p, m.add(nested, m.top[index]))
+	}
+	m.scopes[index] = scope
+
+	return scope
+}
+
+// start is the step of the node that walk starts at
+func (m *matcher) start(path string, node *yaml.Node) step {
+	p := mustParts(path)
+
+	return step{child: child{Path: path, Node: node}, parts: p, states: advance([]*trie{m.absolute}, p)}
+}
+
+// next steps that can be taken from the step in document order (see children), the scopes are the tries of the
+// nested Rule.Rules of the rules that ran on the step
+func (m *matcher) next(from step, scopes []*trie) []step {
+	var res []step
+	for _, c := range children(from.Path, from.Node) {
+		next := step{child: c, opaque: from.opaque}
+		if !next.opaque {
+			part := []string{c.part}
+			next.parts = append(slices.Clip(from.parts), c.part)
+			next.states = advance(from.states, part)
+			next.scoped = advance(append(slices.Clip(from.scoped), scopes...), part)
+			next.opaque = opaque(m.zones, next.parts) || slices.ContainsFunc(next.scoped, func(t *trie) bool {
+				return t.zone
+			})
+		}
+		res = append(res, next)
+	}
+
+	return res
+}
+
+// match returns the indexes of the rules that match the step in the order of the rules passed to walk, including the
+// pending indexes. No rule matches an opaque step, not even a pending Rule.Schema
+func (m *matcher) match(s step, pending []int) []int {
+	if s.opaque {
+		return nil
+	}
+
+	res := slices.Clone(pending)
+	for _, state := range s.states {
+		res = append(res, state.rules...)
+	}
+	for _, state := range s.scoped {
+		res = append(res, state.rules...)
+	}
+	for i, p := range m.patterns {
+		if p.match(s.parts) {
+			res = append(res, m.relative[i])
+		}
+	}
+	slices.SortFunc(res, func(a, b int) int {
+		return cmp.Or(cmp.Compare(m.top[a], m.top[b]), cmp.Compare(a, b)) // nested rules run in the order of their top
+	})
+
+	return slices.Compact(res)
+}
+
+// trie of the parts of the absolute Rule.Path's (or of the nested Rule.Rules), the literal parts are compared
+// case-insensitive (see matchPart)
+type trie struct {
+	literal  map[string]*trie
+	wildcard *trie
+	// rules whose Rule.Path ends at this trie, by index
+	rules []int
+	// zone is true if the Rule.Path of a nested Rule.Opaque ends at this trie
+	zone bool
+}
+
+// add the parts of the Rule.Path of the Rule at index
+func (t *trie) add(parts []string, index int) {
+	cursor := t.insert(parts)
+	cursor.rules = append(cursor.rules, index)
+}
+
+// insert the parts and return the trie they end at
+func (t *trie) insert(parts []string) *trie {
+	cursor := t
+	for _, part := range parts {
+		if part == delimiter+all || part == indexOpen+all+indexClose {
+			if cursor.wildcard == nil {
+				cursor.wildcard = &trie{}
+			}
+			cursor = cursor.wildcard
+			continue
+		}
+
+		key := strings.ToLower(part)
+		if cursor.literal == nil {
+			cursor.literal = map[string]*trie{}
+		}
+		if cursor.literal[key] == nil {
+			cursor.literal[key] = &trie{}
+		}
+		cursor = cursor.literal[key]
+	}
+
+	return cursor
+}
+
+// advance the states by the parts of a path, no states are returned if no Rule.Path can be reached anymore
+func advance(states []*trie, parts []string) []*trie {
+	for _, part := range parts {
+		var next []*trie
+		for _, state := range states {
+			if c := state.literal[strings.ToLower(part)]; c != nil {
+				next = append(next, c)
+			}
+			if state.wildcard != nil {
+				next = append(next, state.wildcard)
+			}
+		}
+		states = next
+	}
+
+	return states
+}
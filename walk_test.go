@@ -0,0 +1,151 @@
+package yamlfmt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestAdvance(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		Path     string
+		Expected []int
+	}{
+		"literal": {
+			Path:     "$.paths",
+			Expected: []int{0},
+		},
+		"case-insensitive": {
+			Path:     "$.Paths",
+			Expected: []int{0},
+		},
+		"literal and wildcard": {
+			Path:     "$.paths./users.get",
+			Expected: []int{1, 2},
+		},
+		"index wildcard": {
+			Path:     "$.tags[0]",
+			Expected: []int{3},
+		},
+		"no rules": {
+			Path:     "$.paths./users.get.responses",
+			Expected: nil,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			root := &trie{}
+			for i, path := range []string{"$.paths", "$.paths[*].get", "$.paths.*[*]", "$.tags[*]"} {
+				root.add(mustParts(path), i)
+			}
+
+			// Act
+			states := advance([]*trie{root}, mustParts(test.Path))
+
+			// Assert
+			var res []int
+			for _, state := range states {
+				res = append(res, state.rules...)
+			}
+			assert.ElementsMatch(t, test.Expected, res)
+		})
+	}
+}
+
+func TestWalk_Order(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte("b:\n  d: 1\na:\n  c: 2\n"), node))
+	rules := []Rule{NewRule("$.a"), NewRule(".d"), NewRule("$", StringOrderingFn), NewRule("$[*]")}
+	var visits []string
+
+	// Act
+	walk(node, rules, func(rule *Rule, _ int, path string, value *yaml.Node) {
+		rule.Run(path, value)
+		visits = append(visits, rule.Path+" "+path)
+	})
+
+	// Assert
+	assert.Equal(t, []string{"$ $", "$.a $.a", "$[*] $.a", "$[*] $.b", ".d $.b.d"}, visits)
+}
+
+func TestLint_NodeOrder(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte("a: [z, {d: 1, c: 2}]\n"), node))
+	rules := []Rule{NewRule("$.a[1]", StringOrderingFn), NewRule("$.a", StringOrderingFn)}
+
+	// Act
+	Lint(node, rules)
+
+	// Assert
+	b, err := yaml.Marshal(node)
+	require.NoError(t, err)
+	assert.Equal(t, "a: [{d: 1, c: 2}, z]\n", string(b))
+}
+
+func TestLint_NestedRules(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte(`a:
+  b: {d: 1, c: 2}
+  example: {f: 3, e: 4}
+b: {d: 1, c: 2}
+c:
+  a:
+    b: {d: 1, c: 2}
+`), node))
+	rules := []Rule{
+		NewRule("$", StringOrderingFn),
+		{Path: ".a", Rules: []Rule{NewRule(".b", StringOrderingFn), NewOpaqueRule(".example")}},
+		NewRule(".example", StringOrderingFn),
+	}
+	var visits []string
+
+	// Act
+	walk(node, rules, func(rule *Rule, index int, path string, value *yaml.Node) {
+		rule.Run(path, value)
+		visits = append(visits, fmt.Sprintf("%d %s %s", index, rule.Path, path))
+	})
+
+	// Assert
+	assert.Equal(t, []string{"0 $ $", "1 .a $.a", "1 .a.b $.a.b", "1 .a $.c.a", "1 .a.b $.c.a.b"}, visits)
+	b, err := yaml.Marshal(node)
+	require.NoError(t, err)
+	assert.Equal(t, `a:
+    b: {c: 2, d: 1}
+    example: {f: 3, e: 4}
+b: {d: 1, c: 2}
+c:
+    a:
+        b: {c: 2, d: 1}
+`, string(b))
+}
+
+func TestLint_NestedRulesOrder(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte("a:\n  b: {d: 1, c: 2}\n"), node))
+	rules := []Rule{
+		{Path: "$.a", Rules: []Rule{NewRule(".b", StringOrderingFn)}},
+		NewRule("$.a.b", NewSimpleOrdering("d")),
+	}
+
+	// Act
+	Lint(node, rules)
+
+	// Assert
+	b, err := yaml.Marshal(node)
+	require.NoError(t, err)
+	assert.Equal(t, "a:\n    b: {d: 1, c: 2}\n", string(b)) // the nested rule of '$.a' runs first
+}
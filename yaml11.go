@@ -0,0 +1,159 @@
+package yamlfmt
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yaml11Types contains the implicit scalar types of YAML 1.1 as documented on https://yaml.org/type/, in resolution
+// order. Any plain scalar not matching one of these patterns is a !!str in YAML 1.1
+var yaml11Types = []struct {
+	Tag     string
+	Pattern *regexp.Regexp
+}{
+	{Tag: "!!null", Pattern: regexp.MustCompile(`^(?:~|null|Null|NULL|)$`)},
+	{Tag: "!!bool", Pattern: regexp.MustCompile(`^(?:y|Y|yes|Yes|YES|n|N|no|No|NO|true|True|TRUE|false|False|FALSE|on|On|ON|off|Off|OFF)$`)},
+	{Tag: "!!int", Pattern: regexp.MustCompile(`^(?:[-+]?0b[0-1_]+|[-+]?0[0-7_]+|[-+]?(?:0|[1-9][0-9_]*)|[-+]?0x[0-9a-fA-F_]+|[-+]?[1-9][0-9_]*(?::[0-5]?[0-9])+)$`)},
+	{Tag: "!!float", Pattern: regexp.MustCompile(`^(?:[-+]?[0-9][0-9_]*\.[0-9_]*(?:[eE][-+][0-9]+)?|[-+]?\.[0-9][0-9_]*(?:[eE][-+][0-9]+)?|[-+]?[0-9][0-9_]*(?::[0-5]?[0-9])+\.[0-9_]*|[-+]?\.(?:inf|Inf|INF)|\.(?:nan|NaN|NAN))$`)},
+	{Tag: "!!timestamp", Pattern: regexp.MustCompile(`^(?:[0-9]{4}-[0-9]{2}-[0-9]{2}|[0-9]{4}-[0-9]{1,2}-[0-9]{1,2}(?:[Tt]|[ \t]+)[0-9]{1,2}:[0-9]{2}:[0-9]{2}(?:\.[0-9]*)?(?:[ \t]*(?:Z|[-+][0-9]{1,2}(?::[0-9]{2})?))?)$`)},
+	{Tag: "!!merge", Pattern: regexp.MustCompile(`^<<$`)},
+	{Tag: "!!value", Pattern: regexp.MustCompile(`^=$`)},
+}
+
+// canonicalNumbers are the forms of the !!int and !!float scalars that YAML 1.1 and 1.2 parsers resolve to the same
+// value: decimal and hexadecimal integers and floats with a fraction (and an exponent with a sign)
+var canonicalNumbers = map[string]*regexp.Regexp{
+	"!!int":   regexp.MustCompile(`^(?:[-+]?(?:0|[1-9][0-9]*)|0x[0-9a-fA-F]+)$`),
+	"!!float": regexp.MustCompile(`^(?:[-+]?(?:[0-9]+\.[0-9]*|\.[0-9]+)(?:[eE][-+][0-9]+)?|[-+]?\.(?:inf|Inf|INF)|\.(?:nan|NaN|NAN))$`),
+}
+
+// Quoted describes a plain scalar that was quoted (or a number that was rewritten) by QuoteYAML11
+type Quoted struct {
+	// Path of the scalar, for a mapping key this is the path of the value of the key
+	Path string
+	// Key is true iff the scalar is a mapping key
+	Key bool
+	// Line of the scalar in the source document
+	Line int
+	// Column of the scalar in the source document
+	Column int
+	// Value of the scalar
+	Value string
+	// Tag a YAML 1.1 parser resolves the plain scalar to, e.g. '!!bool' for 'on'
+	Tag string
+	// Replacement of the Value of a number, e.g. '493' for the octal '0755', empty if the scalar was quoted
+	Replacement string
+}
+
+// QuoteYAML11 quotes every plain string scalar (both keys and values) that a YAML 1.1 parser would resolve to another
+// type, e.g. 'on', 'no', 'y' (!!bool) or '1:20' (!!int), such that the document has the same meaning in YAML 1.1 and 1.2.
+// Numbers that YAML 1.1 and 1.2 parsers read differently (e.g. the octal '0755' or '0o755', or '1e3') are rewritten
+// to the decimal value they decode to, e.g. '493' and '1000.0'. Every quoted scalar is returned in document order
+func QuoteYAML11(node *yaml.Node) []Quoted {
+	if node == nil {
+		return nil
+	}
+
+	path := ""
+	if node.Kind == yaml.DocumentNode {
+		path = root
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+
+	var res []Quoted
+	quoteYAML11(path, node, false, &res)
+
+	return res
+}
+
+// quoteYAML11 recursively quotes node and its children, see QuoteYAML11
+func quoteYAML11(path string, node *yaml.Node, key bool, res *[]Quoted) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Style != 0 {
+			return // only plain scalars are ambiguous
+		}
+
+		if replacement, ok := canonicalNumber(node); ok {
+			*res = append(*res, Quoted{Path: path, Key: key, Line: node.Line, Column: node.Column, Value: node.Value, Tag: yaml11Tag(node.Value), Replacement: replacement})
+			node.Value = replacement
+
+			return
+		}
+
+		if node.ShortTag() != strTag {
+			return
+		}
+
+		tag := yaml11Tag(node.Value)
+		if tag == strTag {
+			return
+		}
+
+		node.Style = yaml.DoubleQuotedStyle
+		*res = append(*res, Quoted{Path: path, Key: key, Line: node.Line, Column: node.Column, Value: node.Value, Tag: tag})
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			childPath := path + delimiter + node.Content[i].Value
+			quoteYAML11(childPath, node.Content[i], true, res)
+			quoteYAML11(childPath, node.Content[i+1], false, res)
+		}
+	case yaml.SequenceNode:
+		for _, c := range children(path, node) {
+			quoteYAML11(c.Path, c.Node, false, res)
+		}
+	case yaml.DocumentNode, yaml.AliasNode:
+	}
+}
+
+// canonicalNumber returns the decimal form of a plain !!int or !!float scalar that is not in one of the
+// canonicalNumbers forms
+func canonicalNumber(node *yaml.Node) (string, bool) {
+	canonical, ok := canonicalNumbers[node.ShortTag()]
+	if !ok || canonical.MatchString(node.Value) {
+		return "", false
+	}
+
+	var value any
+	if node.Decode(&value) != nil {
+		return "", false
+	}
+
+	switch v := value.(type) {
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case float64:
+		mantissa, exponent, found := strings.Cut(strconv.FormatFloat(v, 'g', -1, 64), "e")
+		if !strings.Contains(mantissa, ".") {
+			mantissa += ".0"
+		}
+		if found {
+			return mantissa + "e" + exponent, true
+		}
+
+		return mantissa, true
+	}
+
+	return "", false
+}
+
+// yaml11Tag returns the tag a YAML 1.1 parser resolves a plain scalar value to
+func yaml11Tag(value string) string {
+	for _, t := range yaml11Types {
+		if t.Pattern.MatchString(value) {
+			return t.Tag
+		}
+	}
+
+	return strTag
+}
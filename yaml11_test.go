@@ -0,0 +1,119 @@
+package yamlfmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestQuoteYAML11(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	node := new(yaml.Node)
+	require.NoError(t, yaml.Unmarshal([]byte("on:\n  push: yes\nmode: 0o755\nlist: [y, \"n\", other]\ntime: 1:20\n"), node))
+
+	// Act
+	quoted := QuoteYAML11(node)
+
+	// Assert
+	assert.Equal(t, []Quoted{
+		{Path: "$.on", Key: true, Line: 1, Column: 1, Value: "on", Tag: "!!bool"},
+		{Path: "$.on.push", Line: 2, Column: 9, Value: "yes", Tag: "!!bool"},
+		{Path: "$.mode", Line: 3, Column: 7, Value: "0o755", Tag: "!!str", Replacement: "493"},
+		{Path: "$.list[0]", Line: 4, Column: 8, Value: "y", Tag: "!!bool"},
+		{Path: "$.time", Line: 5, Column: 7, Value: "1:20", Tag: "!!int"},
+	}, quoted)
+}
+
+func TestQuoteYAML11_Numbers(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"0755":   "493",
+		"0o17":   "15",
+		"0b101":  "5",
+		"1_000":  "1000",
+		"1e3":    "1000.0",
+		"2E-7":   "2.0e-07",
+		"12":     "12",
+		"0x1F":   "0x1F",
+		"1.5":    "1.5",
+		"1.0e+3": "1.0e+3",
+		".inf":   ".inf",
+	}
+	for value, expected := range tests {
+		t.Run(value, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			node := new(yaml.Node)
+			require.NoError(t, yaml.Unmarshal([]byte("a: "+value+"\n"), node))
+
+			// Act
+			quoted := QuoteYAML11(node)
+
+			// Assert
+			b, err := yaml.Marshal(node)
+			require.NoError(t, err)
+			assert.Equal(t, "a: "+expected+"\n", string(b))
+			assert.Equal(t, value != expected, len(quoted) == 1)
+		})
+	}
+}
+
+func TestQuoteYAML11_Nil(t *testing.T) {
+	t.Parallel()
+	// Act
+	quoted := QuoteYAML11(nil)
+
+	// Assert
+	assert.Empty(t, quoted)
+}
+
+func TestYAML11Tag(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"~":                   "!!null",
+		"Off":                 "!!bool",
+		"N":                   "!!bool",
+		"0755":                "!!int",
+		"0b1010":              "!!int",
+		"0x_1F":               "!!int",
+		"190:20:30":           "!!int",
+		"1_000.5":             "!!float",
+		".Inf":                "!!float",
+		"2001-12-14":          "!!timestamp",
+		"2001-12-14 21:59:43": "!!timestamp",
+		"<<":                  "!!merge",
+		"=":                   "!!value",
+		"1e3":                 "!!str",
+		"nope":                "!!str",
+	}
+	for value, expected := range tests {
+		t.Run(value, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			actual := yaml11Tag(value)
+
+			// Assert
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestLintBytes_WithYAML11Quoting(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	b := []byte("b: no\na: on\n")
+	var quoted []Quoted
+
+	// Act
+	actual, err := LintBytes(b, []Rule{NewRule("$", StringOrderingFn)}, WithYAML11Quoting(func(q Quoted) {
+		quoted = append(quoted, q)
+	}))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "a: \"on\"\nb: \"no\"\n", string(actual))
+	assert.Len(t, quoted, 2)
+}